@@ -1,14 +1,74 @@
 package finance
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/schema"
 )
 
+const (
+	companiesSourceIEX = "iex"
+	companiesSourceSEC = "sec"
+)
+
 type financeConfig struct {
+	IEXAPIKey             *string `cty:"iex_api_key"`
+	SECUserAgent          *string `cty:"sec_user_agent"`
+	IEXBaseURL            *string `cty:"iex_base_url"`
+	SECDataBaseURL        *string `cty:"sec_data_base_url"`
+	SECArchivesBaseURL    *string `cty:"sec_archives_base_url"`
+	SECFilesBaseURL       *string `cty:"sec_files_base_url"`
+	SECEFTSBaseURL        *string `cty:"sec_efts_base_url"`
+	CompaniesSource       *string `cty:"companies_source"`
+	SECRequestsPerSecond  *int    `cty:"sec_requests_per_second"`
+	MaxRetries            *int    `cty:"max_retries"`
+	MaxDocumentSize       *int    `cty:"max_document_size"`
+	MaxCachedDocumentSize *int    `cty:"max_cached_document_size"`
 }
 
-var ConfigSchema = map[string]*schema.Attribute{}
+var ConfigSchema = map[string]*schema.Attribute{
+	"iex_api_key": {
+		Type: schema.TypeString,
+	},
+	"sec_user_agent": {
+		Type: schema.TypeString,
+	},
+	"iex_base_url": {
+		Type: schema.TypeString,
+	},
+	"sec_data_base_url": {
+		Type: schema.TypeString,
+	},
+	"sec_archives_base_url": {
+		Type: schema.TypeString,
+	},
+	"sec_files_base_url": {
+		Type: schema.TypeString,
+	},
+	"sec_efts_base_url": {
+		Type: schema.TypeString,
+	},
+	"companies_source": {
+		Type: schema.TypeString,
+	},
+	"sec_requests_per_second": {
+		Type: schema.TypeInt,
+	},
+	"max_retries": {
+		Type: schema.TypeInt,
+	},
+	"max_document_size": {
+		Type: schema.TypeInt,
+	},
+	"max_cached_document_size": {
+		Type: schema.TypeInt,
+	},
+}
 
 func ConfigInstance() interface{} {
 	return &financeConfig{}
@@ -22,3 +82,71 @@ func GetConfig(connection *plugin.Connection) financeConfig {
 	config, _ := connection.Config.(financeConfig)
 	return config
 }
+
+// edgarConfig :: build the edgar client config, falling back to the
+// IEX_API_KEY and SEC_USER_AGENT environment variables for unset credentials
+func (c financeConfig) edgarConfig() edgar.Config {
+	config := edgar.Config{
+		IEXToken:           configValue(c.IEXAPIKey, "IEX_API_KEY"),
+		UserAgent:          configValue(c.SECUserAgent, "SEC_USER_AGENT"),
+		IEXBaseURL:         configValue(c.IEXBaseURL, ""),
+		SECDataBaseURL:     configValue(c.SECDataBaseURL, ""),
+		SECArchivesBaseURL: configValue(c.SECArchivesBaseURL, ""),
+		SECFilesBaseURL:    configValue(c.SECFilesBaseURL, ""),
+		SECEFTSBaseURL:     configValue(c.SECEFTSBaseURL, ""),
+		MaxRetries:         c.MaxRetries,
+	}
+	if c.SECRequestsPerSecond != nil {
+		config.SECRequestsPerSecond = *c.SECRequestsPerSecond
+	}
+	return config
+}
+
+// maxDocumentSize :: the most bytes downloaded per filing document, or 0 for
+// the edgar default
+func (c financeConfig) maxDocumentSize() int64 {
+	if c.MaxDocumentSize == nil {
+		return 0
+	}
+	return int64(*c.MaxDocumentSize)
+}
+
+// maxCachedDocumentSize :: the longest document text kept in the connection
+// cache. Defaults to the most bytes downloaded per document, so that every
+// document is cached, and 0 disables the cache.
+func (c financeConfig) maxCachedDocumentSize() int64 {
+	if c.MaxCachedDocumentSize != nil {
+		return int64(*c.MaxCachedDocumentSize)
+	}
+	if size := c.maxDocumentSize(); size > 0 {
+		return size
+	}
+	return edgar.DefaultMaxDocumentSize
+}
+
+// companiesSource :: the backend of the companies table, "iex" or "sec".
+// Defaults to IEX when an API key is available and to SEC otherwise.
+func (c financeConfig) companiesSource() (string, error) {
+	if c.CompaniesSource != nil && *c.CompaniesSource != "" {
+		switch source := strings.ToLower(*c.CompaniesSource); source {
+		case companiesSourceIEX, companiesSourceSEC:
+			return source, nil
+		default:
+			return "", fmt.Errorf("invalid companies_source %q, must be %q or %q", *c.CompaniesSource, companiesSourceIEX, companiesSourceSEC)
+		}
+	}
+	if configValue(c.IEXAPIKey, "IEX_API_KEY") != "" {
+		return companiesSourceIEX, nil
+	}
+	return companiesSourceSEC, nil
+}
+
+func configValue(value *string, envVar string) string {
+	if value != nil && *value != "" {
+		return *value
+	}
+	if envVar != "" {
+		return os.Getenv(envVar)
+	}
+	return ""
+}
@@ -0,0 +1,17 @@
+package finance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+)
+
+// TestMaxCachedDocumentSize caches every downloaded document by default
+func TestMaxCachedDocumentSize(t *testing.T) {
+	require.EqualValues(t, edgar.DefaultMaxDocumentSize, financeConfig{}.maxCachedDocumentSize())
+	require.EqualValues(t, 50<<20, financeConfig{MaxDocumentSize: edgar.Ptr(50 << 20)}.maxCachedDocumentSize())
+	require.EqualValues(t, 1<<20, financeConfig{MaxDocumentSize: edgar.Ptr(50 << 20), MaxCachedDocumentSize: edgar.Ptr(1 << 20)}.maxCachedDocumentSize())
+	require.EqualValues(t, 0, financeConfig{MaxCachedDocumentSize: edgar.Ptr(0)}.maxCachedDocumentSize())
+}
@@ -3,6 +3,8 @@ package finance
 import (
 	"context"
 
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
@@ -15,16 +17,43 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			Schema:      ConfigSchema,
 		},
 		DefaultTransform: transform.FromGo(),
+		// SEC requests are paced by a process-wide limiter in pkg/edgar, which
+		// covers list hydrates and concurrent queries. Column hydrates such as
+		// the sec_filing_content download are also capped at the SEC request
+		// rate within a query, so raising the total limit does not queue more
+		// of them inside the limiter than it lets through in a second.
 		DefaultConcurrency: &plugin.DefaultConcurrencyConfig{
-			TotalMaxConcurrency: 10,
+			TotalMaxConcurrency:   10,
+			DefaultMaxConcurrency: edgar.DefaultSECRequestsPerSecond,
 		},
 		TableMap: map[string]*plugin.Table{
-			"companies":    tableCompanies(ctx),
-			"sec_filers":   tableSecFilers(ctx),
-			"sec_filings":  tableSecFilings(ctx),
-			"quote":        tableFinanceQuote(ctx),
-			"quote_daily":  tableFinanceQuoteDaily(ctx),
-			"quote_hourly": tableFinanceQuoteHourly(ctx),
+			"companies":               tableCompanies(ctx),
+			"sec_filers":              tableSecFilers(ctx),
+			"sec_filings":             tableSecFilings(ctx),
+			"sec_company_fact":        tableSecCompanyFact(ctx),
+			"sec_company_concept":     tableSecCompanyConcept(ctx),
+			"sec_frame":               tableSecFrame(ctx),
+			"sec_filing_document":     tableSecFilingDocument(ctx),
+			"sec_filing_content":      tableSecFilingContent(ctx),
+			"sec_filing_section":      tableSecFilingSection(ctx),
+			"sec_insider_transaction": tableSecInsiderTransaction(ctx),
+			"sec_13f_holding":         tableSec13FHolding(ctx),
+			"sec_8k_event":            tableSec8KEvent(ctx),
+			"sec_filing_index":        tableSecFilingIndex(ctx),
+			"sec_full_text_search":    tableSecFullTextSearch(ctx),
+			"quote":                   tableFinanceQuote(ctx),
+			"quote_daily":             tableFinanceQuoteDaily(ctx),
+			"quote_hourly":            tableFinanceQuoteHourly(ctx),
+			"quote_history":           tableFinanceQuoteHistory(ctx),
+			"quote_equity":            tableFinanceQuoteEquity(ctx),
+			"quote_etf":               tableFinanceQuoteETF(ctx),
+			"quote_mutualfund":        tableFinanceQuoteMutualFund(ctx),
+			"quote_index":             tableFinanceQuoteIndex(ctx),
+			"quote_future":            tableFinanceQuoteFuture(ctx),
+			"quote_forex":             tableFinanceQuoteForex(ctx),
+			"quote_crypto":            tableFinanceQuoteCrypto(ctx),
+			"quote_option":            tableFinanceQuoteOption(ctx),
+			"quote_option_expiration": tableFinanceQuoteOptionExpiration(ctx),
 		},
 	}
 	return p
@@ -2,9 +2,6 @@ package finance
 
 import (
 	"context"
-	"os"
-
-	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
@@ -14,7 +11,7 @@ import (
 func tableCompanies(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
 		Name:        "companies",
-		Description: "US public companies from the SEC Edgar database.",
+		Description: "US public companies from IEX Cloud or the SEC Edgar company tickers list.",
 		List: &plugin.ListConfig{
 			Hydrate: listCompanies,
 		},
@@ -41,13 +38,20 @@ func tableCompanies(ctx context.Context) *plugin.Table {
 
 func listCompanies(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
 	logger := plugin.Logger(ctx)
-	apiKey := os.Getenv("IEX_API_KEY")
-	logger.Info("IEX API key = ", apiKey)
-	if apiKey == "" {
-		panic("No IEX API Key found")
+	client, err := connect(ctx, d)
+	if err != nil {
+		logger.Error("companies.listCompanies", "connection_error", err)
+		return nil, err
+	}
+	source, err := GetConfig(d.Connection).companiesSource()
+	if err != nil {
+		return nil, err
+	}
+	getCompanies := client.GetPublicCompanies
+	if source == companiesSourceSEC {
+		getCompanies = client.GetSECCompanies
 	}
-	client := edgar.NewClient(apiKey)
-	companies, err := client.GetPublicCompanies()
+	companies, err := getCompanies()
 	if err != nil {
 		logger.Error("companies.listCompanies", "query_error", err)
 		return nil, err
@@ -2,6 +2,7 @@ package finance
 
 import (
 	"context"
+	"strings"
 
 	"github.com/piquette/finance-go/quote"
 
@@ -13,76 +14,197 @@ import (
 func tableFinanceQuote(ctx context.Context) *plugin.Table {
 	return &plugin.Table{
 		Name:        "quote",
-		Description: "Most recent available quote for the given symbol.",
+		Description: "Most recent available quote for the given symbols.",
 		List: &plugin.ListConfig{
-			Hydrate:    listQuote,
+			Hydrate:    listQuotes(listQuote),
 			KeyColumns: plugin.SingleColumn("symbol"),
 		},
-		Columns: []*plugin.Column{
-			// Top columns
-			{Name: "symbol", Type: proto.ColumnType_STRING, Description: "Symbol to quote."},
-			{Name: "short_name", Type: proto.ColumnType_STRING, Description: "Short descriptive name for the entity."},
-			{Name: "regular_market_price", Type: proto.ColumnType_DOUBLE, Description: "Price in the regular market."},
-			{Name: "regular_market_time", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("RegularMarketTime").Transform(transform.UnixToTimestamp), Description: "Time when the regular market data was updated."},
-			// Other columns
-			{Name: "ask", Type: proto.ColumnType_DOUBLE, Description: "Ask price. "},
-			{Name: "ask_size", Type: proto.ColumnType_DOUBLE, Description: "Ask size."},
-			{Name: "average_daily_volume_10_day", Type: proto.ColumnType_INT, Description: "Average daily volume - last 10 days."},
-			{Name: "average_daily_volume_3_month", Type: proto.ColumnType_INT, Description: "Average daily volume - last 3 months."},
-			{Name: "bid", Type: proto.ColumnType_DOUBLE, Description: "Bid price."},
-			{Name: "bid_size", Type: proto.ColumnType_DOUBLE, Description: "Bid size."},
-			{Name: "currency_id", Type: proto.ColumnType_STRING, Description: "Currency ID, e.g. AUD, USD."},
-			{Name: "exchange_id", Type: proto.ColumnType_STRING, Description: "Exchange ID, e.g. NYQ, CCC."},
-			{Name: "exchange_timezone_name", Type: proto.ColumnType_STRING, Description: "Timezone at the exchange."},
-			{Name: "exchange_timezone_short_name", Type: proto.ColumnType_STRING, Description: "Timezone short name at the exchange."},
-			{Name: "fifty_day_average", Type: proto.ColumnType_DOUBLE, Description: "50 day average price."},
-			{Name: "fifty_day_average_change", Type: proto.ColumnType_DOUBLE, Description: "50 day average change."},
-			{Name: "fifty_day_average_change_percent", Type: proto.ColumnType_DOUBLE, Description: "50 day average change percentage."},
-			{Name: "fifty_two_week_high", Type: proto.ColumnType_DOUBLE, Description: "52 week high."},
-			{Name: "fifty_two_week_high_change", Type: proto.ColumnType_DOUBLE, Description: "52 week high change."},
-			{Name: "fifty_two_week_high_change_percent", Type: proto.ColumnType_DOUBLE, Description: "52 week high change percentage."},
-			{Name: "fifty_two_week_low", Type: proto.ColumnType_DOUBLE, Description: "52 week low."},
-			{Name: "fifty_two_week_low_change", Type: proto.ColumnType_DOUBLE, Description: "52 week low change."},
-			{Name: "fifty_two_week_low_change_percent", Type: proto.ColumnType_DOUBLE, Description: "52 week low change percent."},
-			{Name: "full_exchange_name", Type: proto.ColumnType_STRING, Description: "Full exchange name."},
-			{Name: "gmt_offset_milliseconds", Type: proto.ColumnType_INT, Transform: transform.FromField("GMTOffSetMilliseconds"), Description: "GMT offset in milliseconds."},
-			{Name: "is_tradeable", Type: proto.ColumnType_BOOL, Description: "True if the symbol is tradeable."},
-			{Name: "market_id", Type: proto.ColumnType_STRING, Description: "Market identifier, e.g. us_market."},
-			{Name: "market_state", Type: proto.ColumnType_STRING, Description: "Current state of the market, e.g. REGULAR, CLOSED."},
-			{Name: "post_market_change", Type: proto.ColumnType_DOUBLE, Description: "Post market price change."},
-			{Name: "post_market_change_percent", Type: proto.ColumnType_DOUBLE, Description: "Post market price change percentage."},
-			{Name: "post_market_price", Type: proto.ColumnType_DOUBLE, Description: "Post market price."},
-			{Name: "post_market_time", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("PostMarketTime").Transform(transform.UnixToTimestamp), Description: "Timestamp for post market data."},
-			{Name: "pre_market_change", Type: proto.ColumnType_DOUBLE, Description: "Pre market price change."},
-			{Name: "pre_market_change_percent", Type: proto.ColumnType_DOUBLE, Description: "Pre market price change percentage."},
-			{Name: "pre_market_price", Type: proto.ColumnType_DOUBLE, Description: "Pre market price."},
-			{Name: "pre_market_time", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("PreMarketTime").Transform(transform.UnixToTimestamp), Description: "Timestamp for pre market data."},
-			{Name: "quote_delay", Type: proto.ColumnType_INT, Description: "Quote delay in minutes."},
-			{Name: "quote_source", Type: proto.ColumnType_STRING, Description: "Quote source."},
-			{Name: "quote_type", Type: proto.ColumnType_STRING, Description: "Quote type, e.g. EQUITY, CRYPTOCURRENCY."},
-			{Name: "regular_market_change", Type: proto.ColumnType_DOUBLE, Description: "Change in price since the regular market open."},
-			{Name: "regular_market_change_percent", Type: proto.ColumnType_DOUBLE, Description: "Change percentage during the regular market session."},
-			{Name: "regular_market_day_high", Type: proto.ColumnType_DOUBLE, Description: "High price for the regular market day."},
-			{Name: "regular_market_day_low", Type: proto.ColumnType_DOUBLE, Description: "Low price for the regular market day."},
-			{Name: "regular_market_open", Type: proto.ColumnType_DOUBLE, Description: "Opening price for the regular market."},
-			{Name: "regular_market_previous_close", Type: proto.ColumnType_DOUBLE, Description: "Close price of the previous regular market session."},
-			{Name: "regular_market_volume", Type: proto.ColumnType_INT, Description: "Trading volume for the regular market session."},
-			{Name: "source_interval", Type: proto.ColumnType_INT, Description: "Source interval in minutes."},
-			{Name: "two_hundred_day_average", Type: proto.ColumnType_DOUBLE, Description: "200 day average price."},
-			{Name: "two_hundred_day_average_change", Type: proto.ColumnType_DOUBLE, Description: "200 day average price change."},
-			{Name: "two_hundred_day_average_change_percent", Type: proto.ColumnType_DOUBLE, Description: "200 day average price change percentage."},
-		},
+		Columns: quoteColumns(),
+	}
+}
+
+// quoteColumns :: the columns shared by quotes of every asset class
+func quoteColumns() []*plugin.Column {
+	return []*plugin.Column{
+		// Top columns
+		{Name: "symbol", Type: proto.ColumnType_STRING, Description: "Symbol to quote."},
+		{Name: "short_name", Type: proto.ColumnType_STRING, Description: "Short descriptive name for the entity."},
+		{Name: "regular_market_price", Type: proto.ColumnType_DOUBLE, Description: "Price in the regular market."},
+		{Name: "regular_market_time", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("RegularMarketTime").Transform(transform.UnixToTimestamp), Description: "Time when the regular market data was updated."},
+		// Other columns
+		{Name: "ask", Type: proto.ColumnType_DOUBLE, Description: "Ask price. "},
+		{Name: "ask_size", Type: proto.ColumnType_DOUBLE, Description: "Ask size."},
+		{Name: "average_daily_volume_10_day", Type: proto.ColumnType_INT, Description: "Average daily volume - last 10 days."},
+		{Name: "average_daily_volume_3_month", Type: proto.ColumnType_INT, Description: "Average daily volume - last 3 months."},
+		{Name: "bid", Type: proto.ColumnType_DOUBLE, Description: "Bid price."},
+		{Name: "bid_size", Type: proto.ColumnType_DOUBLE, Description: "Bid size."},
+		{Name: "currency_id", Type: proto.ColumnType_STRING, Description: "Currency ID, e.g. AUD, USD."},
+		{Name: "exchange_id", Type: proto.ColumnType_STRING, Description: "Exchange ID, e.g. NYQ, CCC."},
+		{Name: "exchange_timezone_name", Type: proto.ColumnType_STRING, Description: "Timezone at the exchange."},
+		{Name: "exchange_timezone_short_name", Type: proto.ColumnType_STRING, Description: "Timezone short name at the exchange."},
+		{Name: "fifty_day_average", Type: proto.ColumnType_DOUBLE, Description: "50 day average price."},
+		{Name: "fifty_day_average_change", Type: proto.ColumnType_DOUBLE, Description: "50 day average change."},
+		{Name: "fifty_day_average_change_percent", Type: proto.ColumnType_DOUBLE, Description: "50 day average change percentage."},
+		{Name: "fifty_two_week_high", Type: proto.ColumnType_DOUBLE, Description: "52 week high."},
+		{Name: "fifty_two_week_high_change", Type: proto.ColumnType_DOUBLE, Description: "52 week high change."},
+		{Name: "fifty_two_week_high_change_percent", Type: proto.ColumnType_DOUBLE, Description: "52 week high change percentage."},
+		{Name: "fifty_two_week_low", Type: proto.ColumnType_DOUBLE, Description: "52 week low."},
+		{Name: "fifty_two_week_low_change", Type: proto.ColumnType_DOUBLE, Description: "52 week low change."},
+		{Name: "fifty_two_week_low_change_percent", Type: proto.ColumnType_DOUBLE, Description: "52 week low change percent."},
+		{Name: "full_exchange_name", Type: proto.ColumnType_STRING, Description: "Full exchange name."},
+		{Name: "gmt_offset_milliseconds", Type: proto.ColumnType_INT, Transform: transform.FromField("GMTOffSetMilliseconds"), Description: "GMT offset in milliseconds."},
+		{Name: "is_tradeable", Type: proto.ColumnType_BOOL, Description: "True if the symbol is tradeable."},
+		{Name: "market_id", Type: proto.ColumnType_STRING, Description: "Market identifier, e.g. us_market."},
+		{Name: "market_state", Type: proto.ColumnType_STRING, Description: "Current state of the market, e.g. REGULAR, CLOSED."},
+		{Name: "post_market_change", Type: proto.ColumnType_DOUBLE, Description: "Post market price change."},
+		{Name: "post_market_change_percent", Type: proto.ColumnType_DOUBLE, Description: "Post market price change percentage."},
+		{Name: "post_market_price", Type: proto.ColumnType_DOUBLE, Description: "Post market price."},
+		{Name: "post_market_time", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("PostMarketTime").Transform(transform.UnixToTimestamp), Description: "Timestamp for post market data."},
+		{Name: "pre_market_change", Type: proto.ColumnType_DOUBLE, Description: "Pre market price change."},
+		{Name: "pre_market_change_percent", Type: proto.ColumnType_DOUBLE, Description: "Pre market price change percentage."},
+		{Name: "pre_market_price", Type: proto.ColumnType_DOUBLE, Description: "Pre market price."},
+		{Name: "pre_market_time", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("PreMarketTime").Transform(transform.UnixToTimestamp), Description: "Timestamp for pre market data."},
+		{Name: "quote_delay", Type: proto.ColumnType_INT, Description: "Quote delay in minutes."},
+		{Name: "quote_source", Type: proto.ColumnType_STRING, Description: "Quote source."},
+		{Name: "quote_type", Type: proto.ColumnType_STRING, Description: "Quote type, e.g. EQUITY, CRYPTOCURRENCY."},
+		{Name: "regular_market_change", Type: proto.ColumnType_DOUBLE, Description: "Change in price since the regular market open."},
+		{Name: "regular_market_change_percent", Type: proto.ColumnType_DOUBLE, Description: "Change percentage during the regular market session."},
+		{Name: "regular_market_day_high", Type: proto.ColumnType_DOUBLE, Description: "High price for the regular market day."},
+		{Name: "regular_market_day_low", Type: proto.ColumnType_DOUBLE, Description: "Low price for the regular market day."},
+		{Name: "regular_market_open", Type: proto.ColumnType_DOUBLE, Description: "Opening price for the regular market."},
+		{Name: "regular_market_previous_close", Type: proto.ColumnType_DOUBLE, Description: "Close price of the previous regular market session."},
+		{Name: "regular_market_volume", Type: proto.ColumnType_INT, Description: "Trading volume for the regular market session."},
+		{Name: "source_interval", Type: proto.ColumnType_INT, Description: "Source interval in minutes."},
+		{Name: "two_hundred_day_average", Type: proto.ColumnType_DOUBLE, Description: "200 day average price."},
+		{Name: "two_hundred_day_average_change", Type: proto.ColumnType_DOUBLE, Description: "200 day average price change."},
+		{Name: "two_hundred_day_average_change_percent", Type: proto.ColumnType_DOUBLE, Description: "200 day average price change percentage."},
+	}
+}
+
+// quoteListMaxLength is the longest comma separated list of symbols sent in
+// one request, keeping the URL within the Yahoo limit
+const quoteListMaxLength = 1500
+
+// quoteLister quotes a list of symbols in one request, adding each quote found
+// by its symbol
+type quoteLister func(symbols []string, add func(symbol string, q interface{})) error
+
+// listQuotes :: a list hydrate quoting every symbol of the query with list.
+// The SDK calls it once per value of a symbol in list, so the call for the
+// first value quotes the whole list, in as few requests as possible, and the
+// others have nothing left to do. A join is not batched, as Postgres scans
+// the table once per row of the other side, each with a single symbol, and
+// waits for each scan to finish before it starts the next.
+func listQuotes(list quoteLister) plugin.HydrateFunc {
+	return func(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+		quals := d.KeyColumnQuals
+		symbol := quals["symbol"].GetStringValue()
+		symbols := querySymbols(d)
+		if len(symbols) == 0 {
+			symbols = []string{symbol}
+		}
+		if symbol != symbols[0] {
+			return nil, nil
+		}
+
+		quotes, err := getQuotes(symbols, list)
+		if err != nil {
+			plugin.Logger(ctx).Error(d.Table.Name+".listQuotes", "query_error", err)
+			return nil, err
+		}
+		for _, q := range quotes {
+			d.StreamListItem(ctx, q)
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		return nil, nil
 	}
 }
 
-func listQuote(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	quals := d.KeyColumnQuals
-	symbol := quals["symbol"].GetStringValue()
-	q, err := quote.Get(symbol)
-	if err != nil {
-		plugin.Logger(ctx).Error("quote.listQuote", "query_error", err)
-		return nil, err
+// listQuote :: quote symbols of any asset class
+func listQuote(symbols []string, add func(string, interface{})) error {
+	iter := quote.List(symbols)
+	for iter.Next() {
+		q := iter.Quote()
+		add(q.Symbol, q)
+	}
+	return iter.Err()
+}
+
+// querySymbols :: every value of the symbol = and in quals of the query, in
+// order. These are read from the query context, as the key column quals of a
+// call split from an in list only hold its own value.
+func querySymbols(d *plugin.QueryData) []string {
+	if d.QueryContext == nil || d.QueryContext.UnsafeQuals["symbol"] == nil {
+		return nil
+	}
+	symbols := []string{}
+	for _, q := range d.QueryContext.UnsafeQuals["symbol"].Quals {
+		if q.GetStringValue() != "=" {
+			continue
+		}
+		if list := q.Value.GetListValue(); list != nil {
+			for _, v := range list.Values {
+				symbols = append(symbols, v.GetStringValue())
+			}
+		} else {
+			symbols = append(symbols, q.Value.GetStringValue())
+		}
+	}
+	return symbols
+}
+
+// getQuotes :: the quotes of the distinct symbols, in order, fetched with as
+// few calls to list as the URL limit allows. Symbols Yahoo doesn't know are
+// left out.
+func getQuotes(symbols []string, list quoteLister) ([]interface{}, error) {
+	found := map[string]interface{}{}
+	add := func(symbol string, q interface{}) {
+		found[strings.ToUpper(symbol)] = q
+	}
+	chunks := chunkSymbols(symbols, quoteListMaxLength)
+	for _, chunk := range chunks {
+		if err := list(chunk, add); err != nil {
+			return nil, err
+		}
+	}
+	quotes := []interface{}{}
+	for _, chunk := range chunks {
+		for _, symbol := range chunk {
+			if q, ok := found[strings.ToUpper(symbol)]; ok {
+				quotes = append(quotes, q)
+			}
+		}
+	}
+	return quotes, nil
+}
+
+// chunkSymbols :: split distinct symbols into lists whose comma separated
+// length is at most maxLength
+func chunkSymbols(symbols []string, maxLength int) [][]string {
+	chunks := [][]string{}
+	seen := map[string]bool{}
+	var chunk []string
+	length := 0
+	for _, symbol := range symbols {
+		key := strings.ToUpper(symbol)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if len(chunk) > 0 && length+1+len(symbol) > maxLength {
+			chunks = append(chunks, chunk)
+			chunk, length = nil, 0
+		}
+		if len(chunk) > 0 {
+			length++
+		}
+		chunk = append(chunk, symbol)
+		length += len(symbol)
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
 	}
-	d.StreamListItem(ctx, q)
-	return nil, nil
+	return chunks
 }
@@ -0,0 +1,43 @@
+package finance
+
+import (
+	"context"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/crypto"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func tableFinanceQuoteCrypto(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "quote_crypto",
+		Description: "Most recent available quote for the given cryptocurrency pair symbols, with supply and 24 hour volume.",
+		List: &plugin.ListConfig{
+			Hydrate:    listQuotes(listCryptoPairs),
+			KeyColumns: plugin.SingleColumn("symbol"),
+		},
+		Columns: append(quoteColumns(),
+			&plugin.Column{Name: "algorithm", Type: proto.ColumnType_STRING, Transform: transform.FromField("Algorithm").Transform(transform.NullIfZeroValue), Description: "Hashing algorithm of the cryptocurrency, e.g. SHA256."},
+			&plugin.Column{Name: "circulating_supply", Type: proto.ColumnType_INT, Description: "Number of coins in circulation."},
+			&plugin.Column{Name: "max_supply", Type: proto.ColumnType_INT, Transform: transform.FromField("MaxSupply").Transform(transform.NullIfZeroValue), Description: "Maximum number of coins that will ever exist, if limited."},
+			&plugin.Column{Name: "start_date", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("StartDate").Transform(transform.UnixToTimestamp), Description: "Launch date of the cryptocurrency."},
+			&plugin.Column{Name: "volume_24_hr", Type: proto.ColumnType_INT, Transform: transform.FromField("VolumeLastDay"), Description: "Trading volume over the last 24 hours, in the quote currency."},
+			&plugin.Column{Name: "volume_all_currencies", Type: proto.ColumnType_INT, Description: "Trading volume over the last 24 hours across all currencies."},
+		),
+	}
+}
+
+// listCryptoPairs :: quote cryptocurrency pairs, skipping symbols of other
+// asset classes
+func listCryptoPairs(symbols []string, add func(string, interface{})) error {
+	iter := crypto.List(symbols)
+	for iter.Next() {
+		if q := iter.CryptoPair(); q.QuoteType == finance.QuoteTypeCryptoPair {
+			add(q.Symbol, q)
+		}
+	}
+	return iter.Err()
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"time"
 
-	"github.com/piquette/finance-go/chart"
 	"github.com/piquette/finance-go/datetime"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
@@ -16,7 +15,7 @@ func tableFinanceQuoteDaily(ctx context.Context) *plugin.Table {
 		Description: "Daily historical quotes for a given symbol.",
 		List: &plugin.ListConfig{
 			Hydrate:    listQuoteDaily,
-			KeyColumns: plugin.SingleColumn("symbol"),
+			KeyColumns: quoteHistoryKeyColumns(),
 		},
 		Columns: usSecHistoryColumns(),
 	}
@@ -26,23 +25,12 @@ func listQuoteDaily(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 	quals := d.KeyColumnQuals
 	symbol := quals["symbol"].GetStringValue()
 
-	// Daily for 121 months (10 years)
-	t := time.Now()
-	sixtyOneMonthsAgo := t.AddDate(0, -121, 0)
-	params := &chart.Params{
-		Symbol:   symbol,
-		Start:    datetime.New(&sixtyOneMonthsAgo),
-		End:      datetime.New(&t),
-		Interval: datetime.OneDay,
+	// Daily for 121 months (10 years) unless the timestamp quals say otherwise
+	start, end := chartTimeRange(d, time.Now())
+	if start.IsZero() {
+		start = end.AddDate(0, -121, 0)
 	}
-
-	iter := chart.Get(params)
-
-	for iter.Next() {
-		b := iter.Bar()
-		d.StreamListItem(ctx, b)
-	}
-	if err := iter.Err(); err != nil {
+	if err := streamChart(ctx, d, symbol, datetime.OneDay, start, end, 0); err != nil {
 		plugin.Logger(ctx).Error("quote_daily.listQuoteDaily", "query_error", err)
 		return nil, err
 	}
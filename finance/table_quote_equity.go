@@ -0,0 +1,51 @@
+package finance
+
+import (
+	"context"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/equity"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func tableFinanceQuoteEquity(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "quote_equity",
+		Description: "Most recent available quote for the given equity symbols, with earnings, dividend and valuation data.",
+		List: &plugin.ListConfig{
+			Hydrate:    listQuotes(listEquities),
+			KeyColumns: plugin.SingleColumn("symbol"),
+		},
+		Columns: append(quoteColumns(),
+			&plugin.Column{Name: "long_name", Type: proto.ColumnType_STRING, Description: "Full name of the company."},
+			&plugin.Column{Name: "book_value", Type: proto.ColumnType_DOUBLE, Description: "Book value per share."},
+			&plugin.Column{Name: "dividend_date", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("DividendDate").Transform(transform.UnixToTimestamp), Description: "Payment date of the most recent or next dividend."},
+			&plugin.Column{Name: "earnings_timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("EarningsTimestamp").Transform(transform.UnixToTimestamp), Description: "Time of the most recent or next earnings announcement."},
+			&plugin.Column{Name: "earnings_timestamp_start", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("EarningsTimestampStart").Transform(transform.UnixToTimestamp), Description: "Start of the window for the next earnings announcement."},
+			&plugin.Column{Name: "earnings_timestamp_end", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("EarningsTimestampEnd").Transform(transform.UnixToTimestamp), Description: "End of the window for the next earnings announcement."},
+			&plugin.Column{Name: "eps_forward", Type: proto.ColumnType_DOUBLE, Description: "Forecast earnings per share for the next 12 months."},
+			&plugin.Column{Name: "eps_trailing_twelve_months", Type: proto.ColumnType_DOUBLE, Description: "Earnings per share over the last 12 months."},
+			&plugin.Column{Name: "forward_pe", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("ForwardPE"), Description: "Price to forecast earnings ratio."},
+			&plugin.Column{Name: "market_cap", Type: proto.ColumnType_INT, Description: "Market capitalization."},
+			&plugin.Column{Name: "price_to_book", Type: proto.ColumnType_DOUBLE, Description: "Price to book value ratio."},
+			&plugin.Column{Name: "shares_outstanding", Type: proto.ColumnType_INT, Description: "Number of shares outstanding."},
+			&plugin.Column{Name: "trailing_annual_dividend_rate", Type: proto.ColumnType_DOUBLE, Description: "Dividends per share paid over the last 12 months."},
+			&plugin.Column{Name: "trailing_annual_dividend_yield", Type: proto.ColumnType_DOUBLE, Description: "Dividends paid over the last 12 months as a fraction of the price."},
+			&plugin.Column{Name: "trailing_pe", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("TrailingPE"), Description: "Price to earnings ratio over the last 12 months."},
+		),
+	}
+}
+
+// listEquities :: quote equities, skipping symbols of other asset classes
+func listEquities(symbols []string, add func(string, interface{})) error {
+	iter := equity.List(symbols)
+	for iter.Next() {
+		if q := iter.Equity(); q.QuoteType == finance.QuoteTypeEquity {
+			add(q.Symbol, q)
+		}
+	}
+	return iter.Err()
+}
@@ -0,0 +1,33 @@
+package finance
+
+import (
+	"context"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/etf"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+func tableFinanceQuoteETF(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "quote_etf",
+		Description: "Most recent available quote for the given ETF symbols, with fund returns.",
+		List: &plugin.ListConfig{
+			Hydrate:    listQuotes(listETFs),
+			KeyColumns: plugin.SingleColumn("symbol"),
+		},
+		Columns: append(quoteColumns(), fundReturnColumns()...),
+	}
+}
+
+// listETFs :: quote ETFs, skipping symbols of other asset classes
+func listETFs(symbols []string, add func(string, interface{})) error {
+	iter := etf.List(symbols)
+	for iter.Next() {
+		if q := iter.ETF(); q.QuoteType == finance.QuoteTypeETF {
+			add(q.Symbol, q)
+		}
+	}
+	return iter.Err()
+}
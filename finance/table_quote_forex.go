@@ -0,0 +1,34 @@
+package finance
+
+import (
+	"context"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/forex"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+func tableFinanceQuoteForex(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "quote_forex",
+		Description: "Most recent available quote for the given foreign exchange pair symbols.",
+		List: &plugin.ListConfig{
+			Hydrate:    listQuotes(listForexPairs),
+			KeyColumns: plugin.SingleColumn("symbol"),
+		},
+		Columns: quoteColumns(),
+	}
+}
+
+// listForexPairs :: quote foreign exchange pairs, skipping symbols of other
+// asset classes
+func listForexPairs(symbols []string, add func(string, interface{})) error {
+	iter := forex.List(symbols)
+	for iter.Next() {
+		if q := iter.ForexPair(); q.QuoteType == finance.QuoteTypeForexPair {
+			add(q.Symbol, q)
+		}
+	}
+	return iter.Err()
+}
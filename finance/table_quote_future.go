@@ -0,0 +1,44 @@
+package finance
+
+import (
+	"context"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/future"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func tableFinanceQuoteFuture(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "quote_future",
+		Description: "Most recent available quote for the given futures contract symbols, with open interest and expiry.",
+		List: &plugin.ListConfig{
+			Hydrate:    listQuotes(listFutures),
+			KeyColumns: plugin.SingleColumn("symbol"),
+		},
+		Columns: append(quoteColumns(),
+			&plugin.Column{Name: "expire_date", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("ExpireDate").Transform(transform.UnixToTimestamp), Description: "Expiry of the contract."},
+			&plugin.Column{Name: "head_symbol", Type: proto.ColumnType_STRING, Transform: transform.FromField("HeadSymbolAsString").Transform(transform.NullIfZeroValue), Description: "Symbol of the continuous contract, e.g. NQ=F, for a dated contract."},
+			&plugin.Column{Name: "is_contract_symbol", Type: proto.ColumnType_BOOL, Description: "True if the symbol is a dated contract, rather than the continuous front month contract."},
+			&plugin.Column{Name: "open_interest", Type: proto.ColumnType_INT, Description: "Number of open contracts."},
+			&plugin.Column{Name: "strike", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("Strike").Transform(transform.NullIfZeroValue), Description: "Strike price, for options on futures."},
+			&plugin.Column{Name: "underlying_exchange_symbol", Type: proto.ColumnType_STRING, Description: "Exchange symbol of the underlying contract, e.g. NQM21.CME."},
+			&plugin.Column{Name: "underlying_symbol", Type: proto.ColumnType_STRING, Description: "Symbol of the underlying asset."},
+		),
+	}
+}
+
+// listFutures :: quote futures contracts, skipping symbols of other asset
+// classes
+func listFutures(symbols []string, add func(string, interface{})) error {
+	iter := future.List(symbols)
+	for iter.Next() {
+		if q := iter.Future(); q.QuoteType == finance.QuoteTypeFuture {
+			add(q.Symbol, q)
+		}
+	}
+	return iter.Err()
+}
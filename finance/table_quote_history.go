@@ -0,0 +1,151 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/piquette/finance-go/datetime"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+const (
+	defaultQuoteInterval = "1d"
+	day                  = 24 * time.Hour
+	// maxAgeSlack is how far a timestamp lower bound may go past the maxAge
+	// of an interval before it is an error
+	maxAgeSlack = time.Minute
+)
+
+// quoteInterval is a bar size supported by the Yahoo chart API, with the
+// limits Yahoo puts on it
+type quoteInterval struct {
+	name string
+	// maxAge is how far back Yahoo keeps bars of this size, 0 if unlimited
+	maxAge time.Duration
+	// maxSpan is the longest range of a single request, 0 if unlimited
+	maxSpan time.Duration
+	// lookback is the range returned without a timestamp lower bound
+	lookback time.Duration
+}
+
+// quoteIntervals :: every supported interval, shortest first
+var quoteIntervals = []quoteInterval{
+	{name: "1m", maxAge: 30 * day, maxSpan: 7 * day, lookback: 7 * day},
+	{name: "2m", maxAge: 60 * day, lookback: 60 * day},
+	{name: "5m", maxAge: 60 * day, lookback: 60 * day},
+	{name: "15m", maxAge: 60 * day, lookback: 60 * day},
+	{name: "30m", maxAge: 60 * day, lookback: 60 * day},
+	{name: "60m", maxAge: 730 * day, lookback: 395 * day},
+	{name: "90m", maxAge: 60 * day, lookback: 60 * day},
+	{name: "1h", maxAge: 730 * day, lookback: 395 * day},
+	{name: "1d", lookback: 3683 * day},
+	{name: "5d", lookback: 3683 * day},
+	{name: "1wk", lookback: 3683 * day},
+	{name: "1mo", lookback: 3683 * day},
+	{name: "3mo", lookback: 3683 * day},
+}
+
+func tableFinanceQuoteHistory(ctx context.Context) *plugin.Table {
+	keyColumns := quoteHistoryKeyColumns()
+	keyColumns = append(keyColumns, &plugin.KeyColumn{Name: "interval", Require: plugin.Optional})
+	return &plugin.Table{
+		Name:        "quote_history",
+		Description: "Historical quotes for a given symbol, at an interval from 1 minute to 3 months.",
+		List: &plugin.ListConfig{
+			Hydrate:    listQuoteHistory,
+			KeyColumns: keyColumns,
+		},
+		Columns: append(usSecHistoryColumns(),
+			&plugin.Column{Name: "interval", Type: proto.ColumnType_STRING, Hydrate: intervalString, Transform: transform.FromValue(), Description: "Size of each bar: 1m, 2m, 5m, 15m, 30m, 60m, 90m, 1h, 1d, 5d, 1wk, 1mo or 3mo. Defaults to 1d."},
+		),
+	}
+}
+
+func listQuoteHistory(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	quals := d.KeyColumnQuals
+	symbol := quals["symbol"].GetStringValue()
+	name := quoteIntervalName(d)
+	interval, ok := findQuoteInterval(name)
+	if !ok {
+		err := fmt.Errorf("unsupported interval %q, must be one of %s", name, strings.Join(quoteIntervalNames(), ", "))
+		logger.Error("quote_history.listQuoteHistory", "qual_error", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	start, end := chartTimeRange(d, now)
+	start, end, err := quoteHistoryRange(interval, start, end, now)
+	if err != nil {
+		logger.Error("quote_history.listQuoteHistory", "qual_error", err)
+		return nil, err
+	}
+
+	// ranges longer than a single request allows are fetched in chunks
+	if err = streamChart(ctx, d, symbol, datetime.Interval(name), start, end, interval.maxSpan); err != nil {
+		logger.Error("quote_history.listQuoteHistory", "query_error", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// quoteHistoryRange :: the chart range for the timestamp quals of a query, which
+// defaults to the lookback of the interval before end. Ranges Yahoo doesn't
+// keep are rejected up front, rather than passing on its empty or unclear
+// response, except for starts less than maxAgeSlack too early, e.g. from
+// now() in Postgres, which is the start of the transaction.
+func quoteHistoryRange(interval quoteInterval, start, end, now time.Time) (time.Time, time.Time, error) {
+	if interval.maxAge == 0 {
+		if start.IsZero() {
+			start = end.Add(-interval.lookback)
+		}
+		return start, end, nil
+	}
+	oldest := now.Add(-interval.maxAge)
+	if start.IsZero() {
+		if end.Before(oldest) {
+			return start, end, fmt.Errorf("interval %s only covers the last %d days, timestamp range ends %s", interval.name, int(interval.maxAge/day), end.Format(time.RFC3339))
+		}
+		start = end.Add(-interval.lookback)
+	} else if start.Before(oldest.Add(-maxAgeSlack)) {
+		return start, end, fmt.Errorf("interval %s only covers the last %d days, timestamp range starts %s", interval.name, int(interval.maxAge/day), start.Format(time.RFC3339))
+	}
+	if start.Before(oldest) {
+		start = oldest
+	}
+	return start, end, nil
+}
+
+// quoteIntervalName :: the interval qual, or the default daily interval
+func quoteIntervalName(d *plugin.QueryData) string {
+	if name := d.KeyColumnQuals["interval"].GetStringValue(); name != "" {
+		return name
+	}
+	return defaultQuoteInterval
+}
+
+func findQuoteInterval(name string) (quoteInterval, bool) {
+	for _, interval := range quoteIntervals {
+		if interval.name == name {
+			return interval, true
+		}
+	}
+	return quoteInterval{}, false
+}
+
+func quoteIntervalNames() []string {
+	names := make([]string, len(quoteIntervals))
+	for i, interval := range quoteIntervals {
+		names[i] = interval.name
+	}
+	return names
+}
+
+func intervalString(_ context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	return quoteIntervalName(d), nil
+}
@@ -0,0 +1,57 @@
+package finance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustQuoteInterval(t *testing.T, name string) quoteInterval {
+	interval, ok := findQuoteInterval(name)
+	require.True(t, ok, name)
+	return interval
+}
+
+func TestQuoteHistoryRange(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 30, 0, 0, time.UTC)
+	minute := mustQuoteInterval(t, "1m")
+	daily := mustQuoteInterval(t, "1d")
+
+	// without a lower bound the lookback of the interval applies
+	start, end, err := quoteHistoryRange(minute, time.Time{}, now, now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-7*day), start)
+	require.Equal(t, now, end)
+
+	start, _, err = quoteHistoryRange(daily, time.Time{}, now, now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-3683*day), start)
+
+	// intervals without a maxAge take any range
+	old := time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC)
+	start, _, err = quoteHistoryRange(daily, old, now, now)
+	require.NoError(t, err)
+	require.Equal(t, old, start)
+
+	// timestamp > now() - interval '30 days', with now() from the start of
+	// the transaction, is clamped to the oldest bar Yahoo keeps
+	start, _, err = quoteHistoryRange(minute, now.Add(-30*day-2*time.Second), now, now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-30*day), start)
+
+	// a lower bound further back is an error
+	_, _, err = quoteHistoryRange(minute, now.Add(-31*day), now, now)
+	require.EqualError(t, err, "interval 1m only covers the last 30 days, timestamp range starts 2026-09-17T15:30:00Z")
+
+	// as is an upper bound alone that is older than Yahoo keeps, rather than
+	// an empty result
+	_, _, err = quoteHistoryRange(minute, time.Time{}, now.Add(-40*day), now)
+	require.EqualError(t, err, "interval 1m only covers the last 30 days, timestamp range ends 2026-09-08T15:30:00Z")
+
+	// an upper bound within maxAge starts at the oldest bar at most
+	start, end, err = quoteHistoryRange(mustQuoteInterval(t, "5m"), time.Time{}, now.Add(-10*day), now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-60*day), start)
+	require.Equal(t, now.Add(-10*day), end)
+}
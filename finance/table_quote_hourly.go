@@ -4,7 +4,6 @@ import (
 	"context"
 	"time"
 
-	"github.com/piquette/finance-go/chart"
 	"github.com/piquette/finance-go/datetime"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
@@ -16,7 +15,7 @@ func tableFinanceQuoteHourly(ctx context.Context) *plugin.Table {
 		Description: "Hourly historical quotes for a given symbol.",
 		List: &plugin.ListConfig{
 			Hydrate:    listQuoteHourly,
-			KeyColumns: plugin.SingleColumn("symbol"),
+			KeyColumns: quoteHistoryKeyColumns(),
 		},
 		Columns: usSecHistoryColumns(),
 	}
@@ -26,23 +25,12 @@ func listQuoteHourly(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	quals := d.KeyColumnQuals
 	symbol := quals["symbol"].GetStringValue()
 
-	// Hourly for 13 months
-	t := time.Now()
-	thirteenMonthsAgo := t.AddDate(0, -13, 0)
-	params := &chart.Params{
-		Symbol:   symbol,
-		Start:    datetime.New(&thirteenMonthsAgo),
-		End:      datetime.New(&t),
-		Interval: datetime.OneHour,
+	// Hourly for 13 months unless the timestamp quals say otherwise
+	start, end := chartTimeRange(d, time.Now())
+	if start.IsZero() {
+		start = end.AddDate(0, -13, 0)
 	}
-
-	iter := chart.Get(params)
-
-	for iter.Next() {
-		b := iter.Bar()
-		d.StreamListItem(ctx, b)
-	}
-	if err := iter.Err(); err != nil {
+	if err := streamChart(ctx, d, symbol, datetime.OneHour, start, end, 0); err != nil {
 		plugin.Logger(ctx).Error("quote_hourly.listQuoteHourly", "query_error", err)
 		return nil, err
 	}
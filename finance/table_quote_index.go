@@ -0,0 +1,33 @@
+package finance
+
+import (
+	"context"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/index"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+func tableFinanceQuoteIndex(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "quote_index",
+		Description: "Most recent available quote for the given market index symbols.",
+		List: &plugin.ListConfig{
+			Hydrate:    listQuotes(listIndexes),
+			KeyColumns: plugin.SingleColumn("symbol"),
+		},
+		Columns: quoteColumns(),
+	}
+}
+
+// listIndexes :: quote market indexes, skipping symbols of other asset classes
+func listIndexes(symbols []string, add func(string, interface{})) error {
+	iter := index.List(symbols)
+	for iter.Next() {
+		if q := iter.Index(); q.QuoteType == finance.QuoteTypeIndex {
+			add(q.Symbol, q)
+		}
+	}
+	return iter.Err()
+}
@@ -0,0 +1,45 @@
+package finance
+
+import (
+	"context"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/mutualfund"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func tableFinanceQuoteMutualFund(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "quote_mutualfund",
+		Description: "Most recent available quote for the given mutual fund symbols, with fund returns.",
+		List: &plugin.ListConfig{
+			Hydrate:    listQuotes(listMutualFunds),
+			KeyColumns: plugin.SingleColumn("symbol"),
+		},
+		Columns: append(quoteColumns(), fundReturnColumns()...),
+	}
+}
+
+// fundReturnColumns :: the return columns of ETFs and mutual funds
+func fundReturnColumns() []*plugin.Column {
+	return []*plugin.Column{
+		{Name: "trailing_three_month_nav_returns", Type: proto.ColumnType_DOUBLE, Description: "Return on net asset value over the last 3 months, as a percentage."},
+		{Name: "trailing_three_month_returns", Type: proto.ColumnType_DOUBLE, Description: "Return on price over the last 3 months, as a percentage."},
+		{Name: "ytd_return", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("YTDReturn"), Description: "Return since the start of the year, as a percentage."},
+	}
+}
+
+// listMutualFunds :: quote mutual funds, skipping symbols of other asset
+// classes
+func listMutualFunds(symbols []string, add func(string, interface{})) error {
+	iter := mutualfund.List(symbols)
+	for iter.Next() {
+		if q := iter.MutualFund(); q.QuoteType == finance.QuoteTypeMutualFund {
+			add(q.Symbol, q)
+		}
+	}
+	return iter.Err()
+}
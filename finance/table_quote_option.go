@@ -0,0 +1,144 @@
+package finance
+
+import (
+	"context"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/datetime"
+	"github.com/piquette/finance-go/options"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func tableFinanceQuoteOption(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "quote_option",
+		Description: "Option contracts on a given underlying symbol, one row per contract of each expiration's chain.",
+		List: &plugin.ListConfig{
+			Hydrate: listQuoteOption,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "symbol", Require: plugin.Required},
+				{Name: "expiration", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+				{Name: "option_type", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			// Top columns
+			{Name: "symbol", Type: proto.ColumnType_STRING, Description: "Symbol of the underlying asset."},
+			{Name: "contract_symbol", Type: proto.ColumnType_STRING, Transform: transform.FromField("Contract.Symbol"), Description: "Symbol of the option contract, e.g. AMZN210507C02240000."},
+			{Name: "option_type", Type: proto.ColumnType_STRING, Description: "Type of the contract: call or put."},
+			{Name: "expiration", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Contract.Expiration").Transform(transform.UnixToTimestamp), Description: "Expiration date of the contract."},
+			{Name: "strike", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("Contract.Strike"), Description: "Strike price of the contract."},
+			// Other columns
+			{Name: "ask", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("Contract.Ask"), Description: "Ask price."},
+			{Name: "bid", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("Contract.Bid"), Description: "Bid price."},
+			{Name: "change", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("Contract.Change"), Description: "Change in price since the previous close."},
+			{Name: "contract_size", Type: proto.ColumnType_STRING, Transform: transform.FromField("Contract.Size"), Description: "Size of the contract, e.g. REGULAR."},
+			{Name: "currency_id", Type: proto.ColumnType_STRING, Transform: transform.FromField("Contract.Currency"), Description: "Currency ID, e.g. USD."},
+			{Name: "implied_volatility", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("Contract.ImpliedVolatility"), Description: "Implied volatility of the contract, as a fraction."},
+			{Name: "in_the_money", Type: proto.ColumnType_BOOL, Transform: transform.FromField("Contract.InTheMoney"), Description: "True if the contract is in the money."},
+			{Name: "last_price", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("Contract.LastPrice"), Description: "Price of the last trade."},
+			{Name: "last_trade_date", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Contract.LastTradeDate").Transform(transform.UnixToTimestamp), Description: "Time of the last trade."},
+			{Name: "open_interest", Type: proto.ColumnType_INT, Transform: transform.FromField("Contract.OpenInterest"), Description: "Number of open contracts."},
+			{Name: "percent_change", Type: proto.ColumnType_DOUBLE, Transform: transform.FromField("Contract.PercentChange"), Description: "Change in price since the previous close, as a percentage."},
+			{Name: "volume", Type: proto.ColumnType_INT, Transform: transform.FromField("Contract.Volume"), Description: "Number of contracts traded today."},
+		},
+	}
+}
+
+// quoteOptionContract is a call or put of an option chain, with its underlying
+type quoteOptionContract struct {
+	Symbol     string
+	OptionType string
+	Contract   *finance.Contract
+}
+
+func listQuoteOption(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	quals := d.KeyColumnQuals
+	symbol := quals["symbol"].GetStringValue()
+	optionType := quals["option_type"].GetStringValue()
+
+	// the first request returns the nearest chain, along with every expiration
+	meta, straddles, err := getOptionChain(ctx, symbol, 0)
+	if err != nil {
+		logger.Error("quote_option.listQuoteOption", "query_error", err)
+		return nil, err
+	}
+	for _, expiration := range optionExpirations(d, meta.AllExpirationDates) {
+		if expiration != meta.ExpirationDate {
+			if _, straddles, err = getOptionChain(ctx, symbol, expiration); err != nil {
+				logger.Error("quote_option.listQuoteOption", "query_error", err, "expiration", expiration)
+				return nil, err
+			}
+		}
+		for _, straddle := range straddles {
+			for _, contract := range []quoteOptionContract{{symbol, "call", straddle.Call}, {symbol, "put", straddle.Put}} {
+				if contract.Contract == nil || (optionType != "" && optionType != contract.OptionType) {
+					continue
+				}
+				c := contract
+				d.StreamListItem(ctx, &c)
+				if d.QueryStatus.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// getOptionChain :: the straddles of a symbol expiring at a unix time, or of
+// the nearest expiration for 0, and the chain's metadata
+func getOptionChain(ctx context.Context, symbol string, expiration int) (*finance.OptionsMeta, []*finance.Straddle, error) {
+	params := &options.Params{UnderlyingSymbol: symbol}
+	params.Context = &ctx
+	if expiration != 0 {
+		params.Expiration = datetime.FromUnix(expiration)
+	}
+	iter := options.GetStraddleP(params)
+	// Meta panics without a response, so check for errors first
+	if err := iter.Err(); err != nil {
+		return nil, nil, err
+	}
+	straddles := []*finance.Straddle{}
+	for iter.Next() {
+		straddles = append(straddles, iter.Straddle())
+	}
+	return iter.Meta(), straddles, iter.Err()
+}
+
+// optionExpirations :: the expirations allowed by the expiration quals. An =
+// qual matches the whole expiration day.
+func optionExpirations(d *plugin.QueryData, all []int) []int {
+	if d.Quals["expiration"] == nil {
+		return all
+	}
+	expirations := []int{}
+	for _, expiration := range all {
+		t := time.Unix(int64(expiration), 0).UTC()
+		match := true
+		for _, q := range d.Quals["expiration"].Quals {
+			bound := q.Value.GetTimestampValue().AsTime().UTC()
+			switch q.Operator {
+			case "=":
+				match = match && t.Format("2006-01-02") == bound.Format("2006-01-02")
+			case ">":
+				match = match && t.After(bound)
+			case ">=":
+				match = match && !t.Before(bound)
+			case "<":
+				match = match && t.Before(bound)
+			case "<=":
+				match = match && !t.After(bound)
+			}
+		}
+		if match {
+			expirations = append(expirations, expiration)
+		}
+	}
+	return expirations
+}
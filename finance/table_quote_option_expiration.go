@@ -0,0 +1,39 @@
+package finance
+
+import (
+	"context"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func tableFinanceQuoteOptionExpiration(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "quote_option_expiration",
+		Description: "Expiration dates of the option contracts listed on a given underlying symbol.",
+		List: &plugin.ListConfig{
+			Hydrate:    listQuoteOptionExpiration,
+			KeyColumns: plugin.SingleColumn("symbol"),
+		},
+		Columns: []*plugin.Column{
+			{Name: "symbol", Type: proto.ColumnType_STRING, Transform: transform.FromQual("symbol"), Description: "Symbol of the underlying asset."},
+			{Name: "expiration", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromValue(), Description: "Expiration date of the option contracts."},
+		},
+	}
+}
+
+func listQuoteOptionExpiration(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	quals := d.KeyColumnQuals
+	symbol := quals["symbol"].GetStringValue()
+	meta, _, err := getOptionChain(ctx, symbol, 0)
+	if err != nil {
+		plugin.Logger(ctx).Error("quote_option_expiration.listQuoteOptionExpiration", "query_error", err)
+		return nil, err
+	}
+	for _, expiration := range meta.AllExpirationDates {
+		d.StreamListItem(ctx, time.Unix(int64(expiration), 0))
+	}
+	return nil, nil
+}
@@ -0,0 +1,155 @@
+package finance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/form"
+	"github.com/stretchr/testify/require"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+// testSymbols returns n distinct symbols of 4 characters
+func testSymbols(n int) []string {
+	symbols := make([]string, n)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("S%03d", i)
+	}
+	return symbols
+}
+
+func TestChunkSymbols(t *testing.T) {
+	// 300 symbols of 4 characters and 299 commas fit in 1499 characters
+	chunks := chunkSymbols(testSymbols(300), quoteListMaxLength)
+	require.Len(t, chunks, 1)
+
+	// a 5 character symbol brings the list to exactly 1500
+	symbols := append([]string{"ABCDE"}, testSymbols(299)...)
+	chunks = chunkSymbols(symbols, quoteListMaxLength)
+	require.Len(t, chunks, 1)
+	require.Len(t, strings.Join(chunks[0], ","), quoteListMaxLength)
+
+	// one more symbol starts a second chunk
+	chunks = chunkSymbols(append(symbols, "NEXT"), quoteListMaxLength)
+	require.Len(t, chunks, 2)
+	require.Len(t, chunks[0], 300)
+	require.Equal(t, []string{"NEXT"}, chunks[1])
+	for _, chunk := range chunks {
+		require.LessOrEqual(t, len(strings.Join(chunk, ",")), quoteListMaxLength)
+	}
+
+	// duplicates are quoted once, whatever their case
+	require.Equal(t, [][]string{{"AAPL", "MSFT"}}, chunkSymbols([]string{"AAPL", "MSFT", "aapl", "AAPL"}, quoteListMaxLength))
+	require.Empty(t, chunkSymbols(nil, quoteListMaxLength))
+}
+
+func TestGetQuotes(t *testing.T) {
+	calls := [][]string{}
+	list := func(symbols []string, add func(string, interface{})) error {
+		calls = append(calls, symbols)
+		// answer in reverse, upper case, and without the unknown symbol
+		for i := len(symbols) - 1; i >= 0; i-- {
+			if symbols[i] != "UNKNOWN" {
+				add(strings.ToUpper(symbols[i]), "quote of "+strings.ToUpper(symbols[i]))
+			}
+		}
+		return nil
+	}
+
+	symbols := append(testSymbols(400), "UNKNOWN", "s001", "msft")
+	quotes, err := getQuotes(symbols, list)
+	require.NoError(t, err)
+	require.Len(t, calls, 2)
+	require.Len(t, calls[0], 300)
+	require.Len(t, quotes, 401)
+	require.Equal(t, "quote of S000", quotes[0])
+	require.Equal(t, "quote of S399", quotes[399])
+	require.Equal(t, "quote of MSFT", quotes[400])
+}
+
+func TestGetQuotesError(t *testing.T) {
+	calls := 0
+	list := func(symbols []string, add func(string, interface{})) error {
+		calls++
+		if calls == 2 {
+			return errors.New("remote error")
+		}
+		for _, symbol := range symbols {
+			add(symbol, symbol)
+		}
+		return nil
+	}
+
+	quotes, err := getQuotes(testSymbols(400), list)
+	require.EqualError(t, err, "remote error")
+	require.Nil(t, quotes)
+	require.Equal(t, 2, calls)
+}
+
+func TestQuerySymbols(t *testing.T) {
+	value := func(s string) *proto.QualValue {
+		return &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: s}}
+	}
+	qual := func(operator string, v *proto.QualValue) *proto.Qual {
+		return &proto.Qual{FieldName: "symbol", Operator: &proto.Qual_StringValue{StringValue: operator}, Value: v}
+	}
+	list := &proto.QualValue{Value: &proto.QualValue_ListValue{ListValue: &proto.QualValueList{Values: []*proto.QualValue{value("AAPL"), value("MSFT")}}}}
+
+	d := &plugin.QueryData{QueryContext: &plugin.QueryContext{UnsafeQuals: map[string]*proto.Quals{
+		"symbol": {Quals: []*proto.Qual{qual("=", list), qual("<>", value("GOOG"))}},
+	}}}
+	require.Equal(t, []string{"AAPL", "MSFT"}, querySymbols(d))
+
+	d.QueryContext.UnsafeQuals["symbol"] = &proto.Quals{Quals: []*proto.Qual{qual("=", value("TSLA"))}}
+	require.Equal(t, []string{"TSLA"}, querySymbols(d))
+
+	require.Nil(t, querySymbols(&plugin.QueryData{QueryContext: &plugin.QueryContext{}}))
+}
+
+// fakeQuoteBackend answers quote requests for every symbol but UNKNOWN, or
+// fails with err
+type fakeQuoteBackend struct {
+	requests []string
+	err      error
+}
+
+func (b *fakeQuoteBackend) Call(_ string, body *form.Values, _ *context.Context, v interface{}) error {
+	symbols := body.Get("symbols")[0]
+	b.requests = append(b.requests, symbols)
+	if b.err != nil {
+		return b.err
+	}
+	results := []map[string]interface{}{}
+	for _, symbol := range strings.Split(symbols, ",") {
+		if symbol != "UNKNOWN" {
+			results = append(results, map[string]interface{}{"symbol": strings.ToUpper(symbol), "quoteType": "EQUITY"})
+		}
+	}
+	data, err := json.Marshal(map[string]interface{}{"quoteResponse": map[string]interface{}{"result": results}})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func TestListQuote(t *testing.T) {
+	backend := &fakeQuoteBackend{}
+	useFakeBackend(t, backend)
+
+	quotes, err := getQuotes([]string{"AAPL", "UNKNOWN", "msft"}, listQuote)
+	require.NoError(t, err)
+	require.Equal(t, []string{"AAPL,UNKNOWN,msft"}, backend.requests)
+	require.Len(t, quotes, 2)
+	require.Equal(t, "AAPL", quotes[0].(*finance.Quote).Symbol)
+	require.Equal(t, "MSFT", quotes[1].(*finance.Quote).Symbol)
+
+	backend.err = errors.New("remote error")
+	_, err = getQuotes([]string{"AAPL"}, listQuote)
+	require.Error(t, err)
+}
@@ -0,0 +1,155 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func tableSec13FHolding(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "sec_13f_holding",
+		Description: "Positions reported by institutional investment managers on Form 13F-HR, one row per holding.",
+		List: &plugin.ListConfig{
+			Hydrate: listSec13FHoldings,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "cik", Require: plugin.Required},
+				{Name: "accession_number", Require: plugin.Optional},
+				{Name: "form", Require: plugin.Optional},
+				{Name: "filing_date", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError,
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "cik", Type: proto.ColumnType_STRING, Transform: transform.FromField("CIK"), Description: "CIK (Central Index Key) of the filing manager."},
+			{Name: "filer_name", Type: proto.ColumnType_STRING, Description: "Name of the filing manager."},
+			{Name: "accession_number", Type: proto.ColumnType_STRING, Description: "Accession number of the filing."},
+			{Name: "form", Type: proto.ColumnType_STRING, Description: "Form of the filing, 13F-HR or 13F-HR/A."},
+			{Name: "filing_date", Type: proto.ColumnType_TIMESTAMP, Description: "Filing date of the filing."},
+			{Name: "report_period", Type: proto.ColumnType_TIMESTAMP, Description: "End of the calendar quarter the holdings are reported for."},
+			{Name: "name_of_issuer", Type: proto.ColumnType_STRING, Description: "Name of the issuer of the security."},
+			{Name: "title_of_class", Type: proto.ColumnType_STRING, Description: "Title of the class of the security, e.g. COM."},
+			{Name: "cusip", Type: proto.ColumnType_STRING, Transform: transform.FromField("CUSIP"), Description: "CUSIP of the security."},
+			{Name: "figi", Type: proto.ColumnType_STRING, Transform: transform.FromField("FIGI").Transform(transform.NullIfZeroValue), Description: "FIGI of the security, if reported."},
+			{Name: "value", Type: proto.ColumnType_DOUBLE, Description: "Market value of the position as reported: in dollars for filings from January 2023, in thousands of dollars before."},
+			{Name: "shares_or_principal_amount", Type: proto.ColumnType_DOUBLE, Description: "Number of shares, or principal amount of debt securities."},
+			{Name: "shares_or_principal_type", Type: proto.ColumnType_STRING, Description: "SH for shares, PRN for principal amount."},
+			{Name: "put_call", Type: proto.ColumnType_STRING, Transform: transform.FromField("PutCall").Transform(transform.NullIfZeroValue), Description: "Put or Call for options positions."},
+			{Name: "investment_discretion", Type: proto.ColumnType_STRING, Description: "Investment discretion: SOLE, DFND (shared-defined) or OTR (shared-other)."},
+			{Name: "other_manager", Type: proto.ColumnType_STRING, Transform: transform.FromField("OtherManager").Transform(transform.NullIfZeroValue), Description: "Sequence numbers of other managers sharing investment discretion."},
+			{Name: "voting_authority_sole", Type: proto.ColumnType_INT, Description: "Shares over which the manager has sole voting authority."},
+			{Name: "voting_authority_shared", Type: proto.ColumnType_INT, Description: "Shares over which the manager has shared voting authority."},
+			{Name: "voting_authority_none", Type: proto.ColumnType_INT, Description: "Shares over which the manager has no voting authority."},
+			{Name: "document_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("DocumentURL"), Description: "URL of the information table XML document."},
+		},
+	}
+}
+
+// sec13FHolding is a position of an information table, with the filing it
+// was reported on
+type sec13FHolding struct {
+	edgar.InstitutionalHolding
+	CIK             string
+	FilerName       *string
+	AccessionNumber string
+	Form            string
+	FilingDate      *time.Time
+	ReportPeriod    *time.Time
+	DocumentURL     string
+}
+
+func listSec13FHoldings(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	client, err := connect(ctx, d)
+	if err != nil {
+		logger.Error("tableSec13FHolding.listSec13FHoldings", "connection_error", err)
+		return nil, err
+	}
+	quals := d.KeyColumnQuals
+	cik := quals["cik"].GetStringValue()
+	accessionNumber := quals["accession_number"].GetStringValue()
+	filer, err := client.GetSubmissions(cik)
+	if err != nil {
+		logger.Error("tableSec13FHolding.listSec13FHoldings", "query_error", err)
+		return nil, err
+	}
+	if filer.CIK == nil {
+		return nil, nil
+	}
+	filter := newFilingFilter(d)
+	err = forEachFilingTable(ctx, d, client, filer, filter, func(table *edgar.FilingTable) error {
+		return stream13FHoldings(ctx, d, client, filer, table, filter, accessionNumber)
+	})
+	if err != nil {
+		logger.Error("tableSec13FHolding.listSec13FHoldings", "query_error", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// stream13FHoldings :: parse the information table of every 13F-HR in the
+// filings table that matches the filter, or only the given accession number
+func stream13FHoldings(ctx context.Context, d *plugin.QueryData, client edgar.Client, filer *edgar.SubmissionsSearchResult, table *edgar.FilingTable, filter *filingFilter, accessionNumber string) error {
+	if table == nil || table.AccessionNumber == nil || table.Form == nil {
+		return nil
+	}
+	for idx, accession := range *table.AccessionNumber {
+		form := (*table.Form)[idx]
+		if !edgar.ThirteenFForms[form] || (accessionNumber != "" && accession != accessionNumber) {
+			continue
+		}
+		filing := edgar.Filing{AccessionNumber: &(*table.AccessionNumber)[idx], Form: &form}
+		if table.FilingDate != nil {
+			filing.FilingDate = edgar.ParseDate((*table.FilingDate)[idx])
+		}
+		if table.ReportDate != nil {
+			filing.ReportDate = edgar.ParseDate((*table.ReportDate)[idx])
+		}
+		if !filter.match(&filing) {
+			continue
+		}
+
+		informationTable, err := client.GetInformationTable(*filer.CIK, accession)
+		if errors.Is(err, edgar.ErrNoInformationTable) {
+			plugin.Logger(ctx).Warn("tableSec13FHolding.stream13FHoldings", "skipped_filing", accession, "error", err)
+			if accessionNumber != "" {
+				return errStopFilings
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("unable to get information table of %s: %w", accession, err)
+		}
+		for _, holding := range informationTable.Holdings {
+			d.StreamListItem(ctx, &sec13FHolding{
+				InstitutionalHolding: holding,
+				CIK:                  edgar.PadCIK(*filer.CIK),
+				FilerName:            filer.Name,
+				AccessionNumber:      accession,
+				Form:                 form,
+				FilingDate:           filing.FilingDate,
+				ReportPeriod:         filing.ReportDate,
+				DocumentURL:          informationTable.URL,
+			})
+		}
+
+		// a single filing was asked for, there is no need to look further
+		if accessionNumber != "" {
+			return errStopFilings
+		}
+		// stop once the query has all the rows it needs
+		if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			return nil
+		}
+	}
+	return nil
+}
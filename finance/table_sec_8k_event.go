@@ -0,0 +1,153 @@
+package finance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func tableSec8KEvent(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "sec_8k_event",
+		Description: "Events reported on Form 8-K current reports, one row per item of each filing.",
+		List: &plugin.ListConfig{
+			Hydrate: listSec8KEvents,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "cik", Require: plugin.Required},
+				{Name: "form", Require: plugin.Optional},
+				{Name: "item", Require: plugin.Optional},
+				{Name: "filing_date", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+				{Name: "acceptance_date_time", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError,
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "cik", Type: proto.ColumnType_STRING, Transform: transform.FromField("CIK"), Description: "CIK (Central Index Key) of the filer."},
+			{Name: "accession_number", Type: proto.ColumnType_STRING, Description: "Accession number of the filing."},
+			{Name: "form", Type: proto.ColumnType_STRING, Description: "Form of the filing, 8-K or 8-K/A."},
+			{Name: "item", Type: proto.ColumnType_STRING, Description: "Item code of the event, e.g. 2.02 or 5.02."},
+			{Name: "item_title", Type: proto.ColumnType_STRING, Description: "Official title of the item, e.g. Results of Operations and Financial Condition. Null for item codes used before August 2004."},
+			{Name: "filing_date", Type: proto.ColumnType_TIMESTAMP, Description: "Filing date of the filing."},
+			{Name: "report_date", Type: proto.ColumnType_TIMESTAMP, Description: "Date of the earliest event reported."},
+			{Name: "acceptance_date_time", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("AcceptanceDateTime"), Description: "Time the filing was accepted by EDGAR."},
+			{Name: "document_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("DocumentURL"), Description: "URL of the primary document of the filing."},
+			{Name: "index_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("IndexURL"), Description: "Index URL of the filing."},
+		},
+	}
+}
+
+// sec8KEvent is one item of an 8-K filing
+type sec8KEvent struct {
+	CIK                string
+	AccessionNumber    string
+	Form               string
+	Item               string
+	ItemTitle          *string
+	FilingDate         *time.Time
+	ReportDate         *time.Time
+	AcceptanceDateTime *time.Time
+	DocumentURL        *string
+	IndexURL           *string
+}
+
+func listSec8KEvents(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	client, err := connect(ctx, d)
+	if err != nil {
+		logger.Error("tableSec8KEvent.listSec8KEvents", "connection_error", err)
+		return nil, err
+	}
+	quals := d.KeyColumnQuals
+	cik := quals["cik"].GetStringValue()
+	filer, err := client.GetSubmissions(cik)
+	if err != nil {
+		logger.Error("tableSec8KEvent.listSec8KEvents", "query_error", err)
+		return nil, err
+	}
+	if filer.CIK == nil {
+		return nil, nil
+	}
+	filter := newFilingFilter(d)
+	items := qualStrings(d, "item")
+	err = forEachFilingTable(ctx, d, client, filer, filter, func(table *edgar.FilingTable) error {
+		return stream8KEvents(ctx, d, client, *filer.CIK, table, filter, items)
+	})
+	if err != nil {
+		logger.Error("tableSec8KEvent.listSec8KEvents", "query_error", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// stream8KEvents :: expand every 8-K in the filings table that matches the
+// filter into one row per item, keeping only the requested items
+func stream8KEvents(ctx context.Context, d *plugin.QueryData, client edgar.Client, cik string, table *edgar.FilingTable, filter *filingFilter, items map[string]bool) error {
+	if table == nil || table.AccessionNumber == nil || table.Form == nil || table.Items == nil {
+		return nil
+	}
+	for idx, accessionNumber := range *table.AccessionNumber {
+		form := (*table.Form)[idx]
+		if !edgar.EightKForms[form] {
+			continue
+		}
+		filing := edgar.Filing{AccessionNumber: &(*table.AccessionNumber)[idx], Form: &form}
+		if table.FilingDate != nil {
+			filing.FilingDate = edgar.ParseDate((*table.FilingDate)[idx])
+		}
+		if table.AcceptanceDateTime != nil {
+			filing.AcceptanceDateTime = &(*table.AcceptanceDateTime)[idx]
+		}
+		if !filter.match(&filing) {
+			continue
+		}
+
+		event := sec8KEvent{
+			CIK:                edgar.PadCIK(cik),
+			AccessionNumber:    accessionNumber,
+			Form:               form,
+			FilingDate:         filing.FilingDate,
+			AcceptanceDateTime: filing.AcceptanceDateTime,
+		}
+		if table.ReportDate != nil {
+			event.ReportDate = edgar.ParseDate((*table.ReportDate)[idx])
+		}
+		indexURL, err := client.FilingIndexURL(cik, accessionNumber)
+		if err != nil {
+			return fmt.Errorf("unable to extract index URL for %s: %w", accessionNumber, err)
+		}
+		event.IndexURL = &indexURL
+		if table.PrimaryDocument != nil && (*table.PrimaryDocument)[idx] != "" {
+			documentURL, err := client.FilingDocumentURL(cik, accessionNumber, (*table.PrimaryDocument)[idx])
+			if err != nil {
+				return fmt.Errorf("unable to extract document URL for %s: %w", accessionNumber, err)
+			}
+			event.DocumentURL = &documentURL
+		}
+
+		for _, item := range edgar.SplitItems((*table.Items)[idx]) {
+			if items != nil && !items[item] {
+				continue
+			}
+			row := event
+			row.Item = item
+			if title, ok := edgar.EightKItems[item]; ok {
+				row.ItemTitle = &title
+			}
+			d.StreamListItem(ctx, &row)
+		}
+
+		// stop once the query has all the rows it needs
+		if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			return nil
+		}
+	}
+	return nil
+}
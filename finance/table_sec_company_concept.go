@@ -0,0 +1,74 @@
+package finance
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+)
+
+func tableSecCompanyConcept(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "sec_company_concept",
+		Description: "Values of a single XBRL concept reported by a company, from the SEC companyconcept API.",
+		List: &plugin.ListConfig{
+			Hydrate: listSecCompanyConcept,
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError,
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "cik", Require: plugin.Required},
+				{Name: "taxonomy", Require: plugin.Required},
+				{Name: "tag", Require: plugin.Required},
+				{Name: "unit", Require: plugin.Optional},
+			},
+		},
+		Columns: secFactColumns("tag"),
+	}
+}
+
+func listSecCompanyConcept(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	client, err := connect(ctx, d)
+	if err != nil {
+		logger.Error("tableSecCompanyConcept.listSecCompanyConcept", "connection_error", err)
+		return nil, err
+	}
+	quals := d.KeyColumnQuals
+	cik := quals["cik"].GetStringValue()
+	taxonomy := quals["taxonomy"].GetStringValue()
+	tag := quals["tag"].GetStringValue()
+	unitQual := quals["unit"].GetStringValue()
+
+	err = companyConcept(client, cik, taxonomy, tag, unitQual, func(row *secFact) bool {
+		d.StreamListItem(ctx, row)
+		return d.QueryStatus.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		logger.Error("tableSecCompanyConcept.listSecCompanyConcept", "query_error", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// companyConcept :: pass each reported value of a single concept, in the unit
+// if set, to emit until it returns false
+func companyConcept(client edgar.Client, cik, taxonomy, tag, unitQual string, emit func(*secFact) bool) error {
+	result, err := client.GetCompanyConcept(cik, taxonomy, tag)
+	if err != nil {
+		return err
+	}
+
+	for _, unit := range sortedKeys(result.Units) {
+		if unitQual != "" && unit != unitQual {
+			continue
+		}
+		for _, f := range result.Units[unit] {
+			if !emit(newSecFact(cik, result.EntityName, taxonomy, tag, result.Label, result.Description, unit, f)) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
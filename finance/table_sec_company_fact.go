@@ -0,0 +1,174 @@
+package finance
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func tableSecCompanyFact(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "sec_company_fact",
+		Description: "XBRL financial data reported by a company, from the SEC companyfacts API.",
+		List: &plugin.ListConfig{
+			Hydrate: listSecCompanyFacts,
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError,
+			},
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "cik", Require: plugin.Required},
+				{Name: "taxonomy", Require: plugin.Optional},
+				{Name: "concept", Require: plugin.Optional},
+				{Name: "unit", Require: plugin.Optional},
+			},
+		},
+		Columns: secFactColumns("concept"),
+	}
+}
+
+// secFact is a single reported value of an XBRL concept, flattened into one
+// row per (taxonomy, concept, unit, period)
+type secFact struct {
+	CIK         string
+	EntityName  *string
+	Taxonomy    string
+	Concept     string
+	Label       *string
+	Description *string
+	Unit        string
+	Val         *float64
+	Start       *time.Time
+	End         *time.Time
+	FY          *int
+	FP          *string
+	Form        *string
+	Filed       *time.Time
+	Accn        *string
+	Frame       *string
+}
+
+// newSecFact :: a row for a reported value. The cik is kept as given in the
+// query, padded or not, as Postgres rechecks the cik qual on every row.
+func newSecFact(cik string, entityName *string, taxonomy, concept string, label, description *string, unit string, f edgar.Fact) *secFact {
+	row := &secFact{
+		CIK:         cik,
+		EntityName:  entityName,
+		Taxonomy:    taxonomy,
+		Concept:     concept,
+		Label:       label,
+		Description: description,
+		Unit:        unit,
+		Val:         f.Val,
+		FY:          f.FY,
+		FP:          f.FP,
+		Form:        f.Form,
+		Accn:        f.Accn,
+		Frame:       f.Frame,
+	}
+	if f.Start != nil {
+		row.Start = edgar.ParseDate(*f.Start)
+	}
+	if f.End != nil {
+		row.End = edgar.ParseDate(*f.End)
+	}
+	if f.Filed != nil {
+		row.Filed = edgar.ParseDate(*f.Filed)
+	}
+	return row
+}
+
+// secFactColumns :: columns of tables streaming secFact rows, conceptColumn
+// names the column holding the XBRL concept
+func secFactColumns(conceptColumn string) []*plugin.Column {
+	return []*plugin.Column{
+		{Name: "cik", Type: proto.ColumnType_STRING, Transform: transform.FromField("CIK"), Description: "CIK (Central Index Key) of the filer."},
+		{Name: "entity_name", Type: proto.ColumnType_STRING, Description: "Name of the filer."},
+		{Name: "taxonomy", Type: proto.ColumnType_STRING, Description: "XBRL taxonomy of the concept, e.g. us-gaap, ifrs-full, dei."},
+		{Name: conceptColumn, Type: proto.ColumnType_STRING, Transform: transform.FromField("Concept"), Description: "XBRL concept (tag), e.g. Revenues, EarningsPerShareDiluted."},
+		{Name: "label", Type: proto.ColumnType_STRING, Description: "Human readable label of the concept."},
+		{Name: "description", Type: proto.ColumnType_STRING, Description: "Description of the concept."},
+		{Name: "unit", Type: proto.ColumnType_STRING, Description: "Unit of measure of the value, e.g. USD, shares, USD/shares."},
+		{Name: "val", Type: proto.ColumnType_DOUBLE, Description: "Reported value."},
+		{Name: "start", Type: proto.ColumnType_TIMESTAMP, Description: "Start of the reporting period, null for values reported at an instant."},
+		{Name: "end", Type: proto.ColumnType_TIMESTAMP, Description: "End of the reporting period, or the instant of the value."},
+		{Name: "fy", Type: proto.ColumnType_INT, Transform: transform.FromField("FY"), Description: "Fiscal year of the filing that reported the value."},
+		{Name: "fp", Type: proto.ColumnType_STRING, Transform: transform.FromField("FP"), Description: "Fiscal period of the filing that reported the value, e.g. FY, Q1."},
+		{Name: "form", Type: proto.ColumnType_STRING, Description: "Form of the filing that reported the value, e.g. 10-K."},
+		{Name: "filed", Type: proto.ColumnType_TIMESTAMP, Description: "Filing date of the filing that reported the value."},
+		{Name: "accn", Type: proto.ColumnType_STRING, Description: "Accession number of the filing that reported the value, joins to sec_filings.accession_number."},
+		{Name: "frame", Type: proto.ColumnType_STRING, Description: "Calendar frame the value is aligned to, e.g. CY2023Q4I, if any."},
+	}
+}
+
+func listSecCompanyFacts(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	client, err := connect(ctx, d)
+	if err != nil {
+		logger.Error("tableSecCompanyFact.listSecCompanyFacts", "connection_error", err)
+		return nil, err
+	}
+	quals := d.KeyColumnQuals
+	cik := quals["cik"].GetStringValue()
+	taxonomyQual := quals["taxonomy"].GetStringValue()
+	conceptQual := quals["concept"].GetStringValue()
+	unitQual := quals["unit"].GetStringValue()
+
+	err = companyFacts(client, cik, taxonomyQual, conceptQual, unitQual, func(row *secFact) bool {
+		d.StreamListItem(ctx, row)
+		return d.QueryStatus.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		logger.Error("tableSecCompanyFact.listSecCompanyFacts", "query_error", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// companyFacts :: pass each reported value of a company matching the
+// taxonomy, concept and unit filters, if set, to emit until it returns false
+func companyFacts(client edgar.Client, cik, taxonomyQual, conceptQual, unitQual string, emit func(*secFact) bool) error {
+	result, err := client.GetCompanyFacts(cik)
+	if err != nil {
+		return err
+	}
+
+	for _, taxonomy := range sortedKeys(result.Facts) {
+		if taxonomyQual != "" && taxonomy != taxonomyQual {
+			continue
+		}
+		concepts := result.Facts[taxonomy]
+		for _, concept := range sortedKeys(concepts) {
+			if conceptQual != "" && concept != conceptQual {
+				continue
+			}
+			c := concepts[concept]
+			for _, unit := range sortedKeys(c.Units) {
+				if unitQual != "" && unit != unitQual {
+					continue
+				}
+				for _, f := range c.Units[unit] {
+					if !emit(newSecFact(cik, result.EntityName, taxonomy, concept, c.Label, c.Description, unit, f)) {
+						return nil
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// sortedKeys :: the keys of a map in a stable order, so rows stream deterministically
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,88 @@
+package finance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+)
+
+// newXBRLTestClient returns an EDGAR client for a test server answering the
+// companyfacts and companyconcept APIs for Apple only
+func newXBRLTestClient(t *testing.T) edgar.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/xbrl/companyfacts/CIK0000320193.json":
+			w.Write([]byte(`{"cik":320193,"entityName":"Apple Inc.","facts":{"dei":{"EntityCommonStockSharesOutstanding":{"units":{"shares":[{"end":"2023-10-20","val":15552752000,"accn":"0000320193-23-000106","fy":2023,"fp":"FY","form":"10-K","filed":"2023-11-03"}]}}},"us-gaap":{"Revenues":{"label":"Revenues","units":{"USD":[{"start":"2021-09-26","end":"2022-09-24","val":394328000000,"accn":"0000320193-22-000108","fy":2022,"fp":"FY","form":"10-K","filed":"2022-10-28"},{"start":"2022-09-25","end":"2023-09-30","val":383285000000,"accn":"0000320193-23-000106","fy":2023,"fp":"FY","form":"10-K","filed":"2023-11-03"}]}}}}}`))
+		case "/api/xbrl/companyconcept/CIK0000320193/us-gaap/AccountsPayableCurrent.json":
+			w.Write([]byte(`{"cik":320193,"taxonomy":"us-gaap","tag":"AccountsPayableCurrent","label":"Accounts Payable, Current","entityName":"Apple Inc.","units":{"USD":[{"end":"2023-09-30","val":62611000000,"accn":"0000320193-23-000106","fy":2023,"fp":"FY","form":"10-K","filed":"2023-11-03"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return edgar.NewClient(edgar.Config{
+		UserAgent:      "Test Suite test@example.com",
+		SECDataBaseURL: server.URL,
+		MaxRetries:     edgar.Ptr(0),
+	})
+}
+
+func collectFacts(rows *[]*secFact) func(*secFact) bool {
+	return func(row *secFact) bool {
+		*rows = append(*rows, row)
+		return true
+	}
+}
+
+// TestCompanyFactsCIK keeps the cik of each row as given in the query, so
+// that Postgres does not filter out the rows of an unpadded cik qual
+func TestCompanyFactsCIK(t *testing.T) {
+	client := newXBRLTestClient(t)
+	for _, cik := range []string{"320193", "0000320193"} {
+		rows := []*secFact{}
+		require.NoError(t, companyFacts(client, cik, "", "", "", collectFacts(&rows)))
+		require.Len(t, rows, 3)
+		for _, row := range rows {
+			require.Equal(t, cik, row.CIK)
+			require.Equal(t, "Apple Inc.", *row.EntityName)
+		}
+
+		rows = []*secFact{}
+		require.NoError(t, companyConcept(client, cik, "us-gaap", "AccountsPayableCurrent", "", collectFacts(&rows)))
+		require.Len(t, rows, 1)
+		require.Equal(t, cik, rows[0].CIK)
+		require.Equal(t, "AccountsPayableCurrent", rows[0].Concept)
+	}
+}
+
+func TestCompanyFactsFilters(t *testing.T) {
+	client := newXBRLTestClient(t)
+
+	rows := []*secFact{}
+	require.NoError(t, companyFacts(client, "320193", "us-gaap", "Revenues", "USD", collectFacts(&rows)))
+	require.Len(t, rows, 2)
+	require.Equal(t, "0000320193-22-000108", *rows[0].Accn)
+	require.Equal(t, 2023, *rows[1].FY)
+
+	rows = []*secFact{}
+	require.NoError(t, companyFacts(client, "320193", "", "", "shares", collectFacts(&rows)))
+	require.Len(t, rows, 1)
+	require.Equal(t, "dei", rows[0].Taxonomy)
+
+	// emit stops the stream once the query has its rows
+	rows = []*secFact{}
+	require.NoError(t, companyFacts(client, "320193", "", "", "", func(row *secFact) bool {
+		rows = append(rows, row)
+		return false
+	}))
+	require.Len(t, rows, 1)
+
+	// a company without facts is a not found error, ignored by the table
+	err := companyFacts(client, "1", "", "", "", collectFacts(&rows))
+	require.True(t, isNotFoundError(context.Background(), nil, nil, err))
+}
@@ -2,9 +2,7 @@ package finance
 
 import (
 	"context"
-	"os"
 
-	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
@@ -23,8 +21,8 @@ func tableSecFilers(ctx context.Context) *plugin.Table {
 			{Name: "entity_type", Type: proto.ColumnType_STRING, Description: "Entity type of the filer."},
 			{Name: "sic", Type: proto.ColumnType_INT, Transform: transform.FromField("SIC").Transform(transformStringToInt), Description: "SIC (Standard Industrial Classification) of the filer."},
 			{Name: "sic_description", Type: proto.ColumnType_STRING, Transform: transform.FromField("SICDescription"), Description: "SIC (Standard Industrial Classification) description of the filer."},
-			{Name: "insider_transaction_for_owner_exists", Type: proto.ColumnType_INT, Description: "Whether or not an insider transaction for ther issuer of the filer exists."},
-			{Name: "insider_transaction_for_issuer_exists", Type: proto.ColumnType_INT, Description: "Whether or not an insider transaction for ther owner of the filer exists."},
+			{Name: "insider_transaction_for_owner_exists", Type: proto.ColumnType_BOOL, Transform: transform.FromField("InsiderTransactionForOwnerExists").Transform(transformIntToBool), Description: "Whether or not the filer has filed insider transactions as a reporting owner."},
+			{Name: "insider_transaction_for_issuer_exists", Type: proto.ColumnType_BOOL, Transform: transform.FromField("InsiderTransactionForIssuerExists").Transform(transformIntToBool), Description: "Whether or not insider transactions have been filed for the filer as an issuer."},
 			{Name: "name", Type: proto.ColumnType_STRING, Description: "Name of the filer."},
 			{Name: "tickers", Type: proto.ColumnType_JSON, Description: "Ticker of the filer."},
 			{Name: "exchanges", Type: proto.ColumnType_JSON, Description: "Exchanges on which the filer trades."},
@@ -36,7 +34,12 @@ func tableSecFilers(ctx context.Context) *plugin.Table {
 			{Name: "fiscal_year_end", Type: proto.ColumnType_STRING, Description: "Fiscal year end of the filer."},
 			{Name: "state_of_incorporation", Type: proto.ColumnType_STRING, Description: "State of incorporation of the filer."},
 			{Name: "state_of_incorporation_description", Type: proto.ColumnType_STRING, Description: "State of incorporation description of the filer."},
-			{Name: "addresses", Type: proto.ColumnType_STRING, Description: "Addresses of the filer."},
+			{Name: "addresses", Type: proto.ColumnType_JSON, Description: "Business and mailing addresses of the filer."},
+			{Name: "business_address", Type: proto.ColumnType_JSON, Transform: transform.FromField("Addresses.Business"), Description: "Business address of the filer."},
+			{Name: "business_city", Type: proto.ColumnType_STRING, Transform: transform.FromField("Addresses.Business.City").Transform(transform.NullIfZeroValue), Description: "City of the business address of the filer."},
+			{Name: "business_state", Type: proto.ColumnType_STRING, Transform: transform.FromField("Addresses.Business.StateOrCountry").Transform(transform.NullIfZeroValue), Description: "State or country code of the business address of the filer, e.g. CA."},
+			{Name: "business_zip", Type: proto.ColumnType_STRING, Transform: transform.FromField("Addresses.Business.ZipCode").Transform(transform.NullIfZeroValue), Description: "Zip code of the business address of the filer."},
+			{Name: "mailing_address", Type: proto.ColumnType_JSON, Transform: transform.FromField("Addresses.Mailing"), Description: "Mailing address of the filer."},
 			{Name: "phone", Type: proto.ColumnType_STRING, Description: "Phone of the filer."},
 			{Name: "flags", Type: proto.ColumnType_STRING, Description: "Flags of the filer."},
 			{Name: "former_names", Type: proto.ColumnType_JSON, Description: "Former names of the filer."},
@@ -45,14 +48,12 @@ func tableSecFilers(ctx context.Context) *plugin.Table {
 }
 
 func listSecFiler(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	// TODO: move client init to main() in main.go
 	logger := plugin.Logger(ctx)
-	apiKey := os.Getenv("IEX_API_KEY")
-	logger.Info("IEX API key = ", apiKey)
-	if apiKey == "" {
-		panic("No IEX API Key found")
+	client, err := connect(ctx, d)
+	if err != nil {
+		logger.Error("tableSecFilers.listSecFiler", "connection_error", err)
+		return nil, err
 	}
-	client := edgar.NewClient(apiKey)
 	quals := d.KeyColumnQuals
 	cik := quals["cik"].GetStringValue()
 	filer, err := client.GetSubmissions(cik)
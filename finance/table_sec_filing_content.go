@@ -0,0 +1,96 @@
+package finance
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func tableSecFilingContent(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "sec_filing_content",
+		Description: "Plain text content of a document from the SEC Edgar archives, e.g. the primary document of a filing.",
+		List: &plugin.ListConfig{
+			Hydrate: listSecFilingContent,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "url", Require: plugin.Required},
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "url", Type: proto.ColumnType_STRING, Transform: transform.FromField("URL"), Description: "URL of the document in the SEC archives, e.g. the primary_document of sec_filings."},
+			{Name: "content_type", Type: proto.ColumnType_STRING, Hydrate: getSecFilingContent, Description: "Content type of the document as served by SEC, e.g. text/html."},
+			{Name: "size", Type: proto.ColumnType_INT, Hydrate: getSecFilingContent, Description: "Number of bytes downloaded, at most max_document_size."},
+			{Name: "truncated", Type: proto.ColumnType_BOOL, Hydrate: getSecFilingContent, Description: "True if the document was larger than max_document_size and was cut short."},
+			{Name: "text", Type: proto.ColumnType_STRING, Hydrate: getSecFilingContent, Description: "Plain text of the document with HTML and inline XBRL markup removed. Null for binary documents such as PDFs and images."},
+		},
+	}
+}
+
+// secFilingContent is a downloaded document, reduced to its text
+type secFilingContent struct {
+	URL         string
+	ContentType string
+	Size        int
+	Truncated   bool
+	Text        *string
+}
+
+func listSecFilingContent(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	url := d.KeyColumnQuals["url"].GetStringValue()
+	if url != "" {
+		d.StreamListItem(ctx, &secFilingContent{URL: url})
+	}
+	return nil, nil
+}
+
+// getSecFilingContent :: download the document only when one of its content
+// columns is selected
+func getSecFilingContent(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	item := h.Item.(*secFilingContent)
+	content, err := getDocumentContent(ctx, d, item.URL)
+	if err != nil {
+		plugin.Logger(ctx).Error("tableSecFilingContent.getSecFilingContent", "query_error", err)
+		return nil, err
+	}
+	return content, nil
+}
+
+// getDocumentContent :: download a document from the SEC archives and extract
+// its text. Only the text is kept, in the connection cache, so repeated
+// queries don't download the same document again. Text longer than the
+// max_cached_document_size connection option is not cached.
+func getDocumentContent(ctx context.Context, d *plugin.QueryData, url string) (*secFilingContent, error) {
+	cacheKey := "sec_filing_content-" + url
+	if cachedData, ok := d.ConnectionCache.Get(ctx, cacheKey); ok {
+		return cachedData.(*secFilingContent), nil
+	}
+
+	client, err := connect(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := client.GetDocument(url, GetConfig(d.Connection).maxDocumentSize())
+	if err != nil {
+		return nil, err
+	}
+	content := &secFilingContent{
+		URL:         url,
+		ContentType: doc.ContentType,
+		Size:        len(doc.Body),
+		Truncated:   doc.Truncated,
+	}
+	if doc.IsText() {
+		text := doc.Text()
+		content.Text = &text
+	}
+
+	maxCached := GetConfig(d.Connection).maxCachedDocumentSize()
+	if maxCached > 0 && (content.Text == nil || int64(len(*content.Text)) <= maxCached) {
+		if err := d.ConnectionCache.Set(ctx, cacheKey, content); err != nil {
+			plugin.Logger(ctx).Warn("getDocumentContent", "cache_error", err)
+		}
+	}
+	return content, nil
+}
@@ -0,0 +1,68 @@
+package finance
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func tableSecFilingDocument(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "sec_filing_document",
+		Description: "Documents within a filing from the SEC Edgar database, e.g. the primary document, exhibits and XBRL files.",
+		List: &plugin.ListConfig{
+			Hydrate: listSecFilingDocuments,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "cik", Require: plugin.Required},
+				{Name: "accession_number", Require: plugin.Required},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError,
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "cik", Type: proto.ColumnType_STRING, Transform: transform.FromField("CIK"), Description: "CIK (Central Index Key) of the filer."},
+			{Name: "accession_number", Type: proto.ColumnType_STRING, Description: "Accession number of the filing."},
+			{Name: "sequence", Type: proto.ColumnType_INT, Description: "Sequence of the document within the filing, null for files not listed on the filing index page."},
+			{Name: "type", Type: proto.ColumnType_STRING, Description: "Type of the document, e.g. 10-K, EX-21.1, GRAPHIC, EX-101.INS."},
+			{Name: "description", Type: proto.ColumnType_STRING, Description: "Description of the document."},
+			{Name: "file_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("Name"), Description: "File name of the document."},
+			{Name: "size", Type: proto.ColumnType_INT, Description: "Size of the document in bytes."},
+			{Name: "last_modified", Type: proto.ColumnType_TIMESTAMP, Description: "Time the document was last modified in the archive."},
+			{Name: "url", Type: proto.ColumnType_STRING, Transform: transform.FromField("URL"), Description: "URL of the document."},
+		},
+	}
+}
+
+// secFilingDocument is a single document of a filing, with the keys it was listed by
+type secFilingDocument struct {
+	edgar.FilingDocument
+	CIK             string
+	AccessionNumber string
+}
+
+func listSecFilingDocuments(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	client, err := connect(ctx, d)
+	if err != nil {
+		logger.Error("tableSecFilingDocument.listSecFilingDocuments", "connection_error", err)
+		return nil, err
+	}
+	quals := d.KeyColumnQuals
+	cik := quals["cik"].GetStringValue()
+	accessionNumber := quals["accession_number"].GetStringValue()
+
+	documents, err := client.GetFilingDocuments(cik, accessionNumber)
+	if err != nil {
+		logger.Error("tableSecFilingDocument.listSecFilingDocuments", "query_error", err)
+		return nil, err
+	}
+	for _, doc := range *documents {
+		d.StreamListItem(ctx, &secFilingDocument{FilingDocument: doc, CIK: cik, AccessionNumber: accessionNumber})
+	}
+	return nil, nil
+}
@@ -0,0 +1,218 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+// dailyIndexMaxDays is the longest filing date range read from daily indexes,
+// one download per day, rather than from the quarterly full index
+const dailyIndexMaxDays = 7
+
+func tableSecFilingIndex(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "sec_filing_index",
+		Description: "Every filing made to the SEC Edgar database, across all companies, from the EDGAR full and daily indexes.",
+		List: &plugin.ListConfig{
+			Hydrate: listSecFilingIndex,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "filing_date", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+				{Name: "year", Require: plugin.Optional},
+				{Name: "quarter", Require: plugin.Optional},
+				{Name: "form", Require: plugin.Optional},
+				{Name: "cik", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "cik", Type: proto.ColumnType_STRING, Transform: transform.FromField("CIK"), Description: "CIK (Central Index Key) of the filer."},
+			{Name: "company_name", Type: proto.ColumnType_STRING, Description: "Name of the filer."},
+			{Name: "form", Type: proto.ColumnType_STRING, Transform: transform.FromField("FormType"), Description: "Form of the filing, e.g. 10-K or 8-K."},
+			{Name: "filing_date", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("DateFiled"), Description: "Filing date of the filing."},
+			{Name: "accession_number", Type: proto.ColumnType_STRING, Description: "Accession number of the filing."},
+			{Name: "file_name", Type: proto.ColumnType_STRING, Transform: transform.FromField("Filename"), Description: "Path of the complete submission text file within the archives, e.g. edgar/data/320193/0000320193-23-000106.txt."},
+			{Name: "url", Type: proto.ColumnType_STRING, Transform: transform.FromField("URL"), Description: "URL of the complete submission text file."},
+			{Name: "index_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("IndexURL"), Description: "Index URL of the filing."},
+			{Name: "year", Type: proto.ColumnType_INT, Description: "Calendar year of the filing date."},
+			{Name: "quarter", Type: proto.ColumnType_INT, Description: "Calendar quarter, 1 to 4, of the filing date."},
+		},
+	}
+}
+
+// secFilingIndexEntry is a filing from a master index, with its URLs
+type secFilingIndexEntry struct {
+	edgar.IndexEntry
+	CIK      string
+	IndexURL string
+	Year     int
+	Quarter  int
+}
+
+func listSecFilingIndex(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	from, to, err := filingIndexRange(d, time.Now().UTC())
+	if err != nil {
+		logger.Error("tableSecFilingIndex.listSecFilingIndex", "qual_error", err)
+		return nil, err
+	}
+	client, err := connect(ctx, d)
+	if err != nil {
+		logger.Error("tableSecFilingIndex.listSecFilingIndex", "connection_error", err)
+		return nil, err
+	}
+	filter := newFilingFilter(d)
+	ciks := qualCIKs(d, "cik")
+
+	stream := func(entries *[]edgar.IndexEntry) error {
+		for _, entry := range *entries {
+			if entry.DateFiled.Before(from) || entry.DateFiled.After(to) {
+				continue
+			}
+			if !filter.match(&edgar.Filing{Form: &entry.FormType, FilingDate: &entry.DateFiled}) {
+				continue
+			}
+			cik, ok := matchCIK(ciks, entry.CIK)
+			if !ok {
+				continue
+			}
+			indexURL, err := client.FilingIndexURL(entry.CIK, entry.AccessionNumber)
+			if err != nil {
+				return fmt.Errorf("unable to extract index URL for %s: %w", entry.AccessionNumber, err)
+			}
+			d.StreamListItem(ctx, &secFilingIndexEntry{
+				IndexEntry: entry,
+				CIK:        cik,
+				IndexURL:   indexURL,
+				Year:       entry.DateFiled.Year(),
+				Quarter:    edgar.Quarter(entry.DateFiled),
+			})
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return errStopFilings
+			}
+		}
+		return nil
+	}
+
+	// short ranges are read day by day, anything longer a quarter at a time
+	if to.Sub(from) < dailyIndexMaxDays*24*time.Hour {
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			entries, err := client.GetDailyIndex(day)
+			if err == nil {
+				err = stream(entries)
+			}
+			if errors.Is(err, errStopFilings) {
+				return nil, nil
+			}
+			if err != nil {
+				logger.Error("tableSecFilingIndex.listSecFilingIndex", "query_error", err, "date", day)
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+	for quarter := quarterStart(from); !quarter.After(to); quarter = quarter.AddDate(0, 3, 0) {
+		entries, err := client.GetFullIndex(quarter.Year(), edgar.Quarter(quarter))
+		if err == nil {
+			err = stream(entries)
+		}
+		if errors.Is(err, errStopFilings) {
+			return nil, nil
+		}
+		if err != nil {
+			logger.Error("tableSecFilingIndex.listSecFilingIndex", "query_error", err, "quarter", quarter)
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// filingIndexRange :: the first and last filing dates allowed by the
+// filing_date, year and quarter quals, up to today. A lower bound is
+// required, as the full index goes back to 1993.
+func filingIndexRange(d *plugin.QueryData, now time.Time) (time.Time, time.Time, error) {
+	from, to := filingDateBounds(d)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if to.IsZero() || to.After(today) {
+		to = today
+	}
+
+	quals := d.KeyColumnQuals
+	year := int(quals["year"].GetInt64Value())
+	quarter := int(quals["quarter"].GetInt64Value())
+	if quarter != 0 && (quarter < 1 || quarter > 4) {
+		return from, to, fmt.Errorf("quarter must be between 1 and 4, got %d", quarter)
+	}
+	if quarter != 0 && year == 0 {
+		return from, to, errors.New("quarter must be used with year")
+	}
+	if year != 0 {
+		first, last := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+		if quarter != 0 {
+			first = time.Date(year, time.Month(3*quarter-2), 1, 0, 0, 0, 0, time.UTC)
+			last = first.AddDate(0, 3, -1)
+		}
+		from, to = laterOf(from, first), earlierOf(to, last)
+	}
+
+	if from.IsZero() {
+		return from, to, errors.New("sec_filing_index requires a filing_date lower bound, or a year")
+	}
+	return from, to, nil
+}
+
+// filingDateBounds :: the first and last whole days allowed by the
+// filing_date quals, each zero if unbounded
+func filingDateBounds(d *plugin.QueryData) (from time.Time, to time.Time) {
+	if d.Quals["filing_date"] == nil {
+		return from, to
+	}
+	for _, q := range d.Quals["filing_date"].Quals {
+		t := q.Value.GetTimestampValue().AsTime().UTC()
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		switch q.Operator {
+		case "=":
+			from = laterOf(from, day)
+			to = earlierOf(to, day)
+		case ">", ">=":
+			// filing dates have no time of day, a bound part way through a
+			// day starts from the next
+			if q.Operator == ">" || !t.Equal(day) {
+				day = day.AddDate(0, 0, 1)
+			}
+			from = laterOf(from, day)
+		case "<", "<=":
+			if q.Operator == "<" && t.Equal(day) {
+				day = day.AddDate(0, 0, -1)
+			}
+			to = earlierOf(to, day)
+		}
+	}
+	return from, to
+}
+
+// quarterStart :: the first day of the calendar quarter of a date
+func quarterStart(t time.Time) time.Time {
+	return time.Date(t.Year(), time.Month(3*edgar.Quarter(t)-2), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func laterOf(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// earlierOf :: the earlier of two times, where a zero time is unbounded
+func earlierOf(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,115 @@
+package finance
+
+import (
+	"context"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func tableSecFilingSection(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "sec_filing_section",
+		Description: "Items of a 10-K or 10-Q report from the SEC Edgar database, e.g. Item 1A Risk Factors or Item 7 MD&A, split from the text of the primary document.",
+		List: &plugin.ListConfig{
+			Hydrate: listSecFilingSections,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "cik", Require: plugin.Required},
+				{Name: "accession_number", Require: plugin.Required},
+				{Name: "item", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError,
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "cik", Type: proto.ColumnType_STRING, Transform: transform.FromField("CIK"), Description: "CIK (Central Index Key) of the filer."},
+			{Name: "accession_number", Type: proto.ColumnType_STRING, Description: "Accession number of the filing."},
+			{Name: "form", Type: proto.ColumnType_STRING, Description: "Form type of the primary document, e.g. 10-K, 10-Q or 10-K/A."},
+			{Name: "document_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("DocumentURL"), Description: "URL of the primary document the section was taken from."},
+			{Name: "part", Type: proto.ColumnType_STRING, Description: "Part of the report the item belongs to, e.g. I or II."},
+			{Name: "item", Type: proto.ColumnType_STRING, Description: "Item number, e.g. 1A or 7. Item numbers restart in part II of a 10-Q."},
+			{Name: "title", Type: proto.ColumnType_STRING, Description: "Standard title of the item, e.g. Risk Factors."},
+			{Name: "text", Type: proto.ColumnType_STRING, Description: "Plain text of the section, without its heading."},
+		},
+	}
+}
+
+// secFilingSection is one item of a report, with the filing it was taken from
+type secFilingSection struct {
+	edgar.Section
+	CIK             string
+	AccessionNumber string
+	Form            string
+	DocumentURL     string
+}
+
+func listSecFilingSections(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	client, err := connect(ctx, d)
+	if err != nil {
+		logger.Error("tableSecFilingSection.listSecFilingSections", "connection_error", err)
+		return nil, err
+	}
+	quals := d.KeyColumnQuals
+	cik := quals["cik"].GetStringValue()
+	accessionNumber := quals["accession_number"].GetStringValue()
+	item := strings.ToUpper(quals["item"].GetStringValue())
+
+	documents, err := client.GetFilingDocuments(cik, accessionNumber)
+	if err != nil {
+		logger.Error("tableSecFilingSection.listSecFilingSections", "query_error", err)
+		return nil, err
+	}
+	primary := primaryDocument(*documents)
+	if primary == nil {
+		logger.Warn("tableSecFilingSection.listSecFilingSections", "no_primary_document", accessionNumber)
+		return nil, nil
+	}
+	form := *primary.Type
+
+	content, err := getDocumentContent(ctx, d, primary.URL)
+	if err != nil {
+		logger.Error("tableSecFilingSection.listSecFilingSections", "query_error", err)
+		return nil, err
+	}
+	if content.Text == nil {
+		return nil, nil
+	}
+
+	for _, section := range edgar.SplitSections(form, *content.Text) {
+		if item != "" && section.Item != item {
+			continue
+		}
+		d.StreamListItem(ctx, &secFilingSection{
+			Section:         section,
+			CIK:             cik,
+			AccessionNumber: accessionNumber,
+			Form:            form,
+			DocumentURL:     primary.URL,
+		})
+		if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			break
+		}
+	}
+	return nil, nil
+}
+
+// primaryDocument :: the first document listed on the filing index page,
+// whose type is the form of the filing
+func primaryDocument(documents []edgar.FilingDocument) *edgar.FilingDocument {
+	var primary *edgar.FilingDocument
+	for i, doc := range documents {
+		if doc.Sequence == nil || doc.Type == nil {
+			continue
+		}
+		if primary == nil || *doc.Sequence < *primary.Sequence {
+			primary = &documents[i]
+		}
+	}
+	return primary
+}
@@ -2,15 +2,15 @@ package finance
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
-	"strconv"
-	"strings"
+	"time"
 
 	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/quals"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
 )
 
@@ -19,23 +19,28 @@ func tableSecFilings(ctx context.Context) *plugin.Table {
 		Name:        "sec_filings",
 		Description: "US public company filings from the SEC Edgar database.",
 		List: &plugin.ListConfig{
-			Hydrate:    listSecFilings,
-			KeyColumns: plugin.SingleColumn("cik"),
+			Hydrate: listSecFilings,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "cik", Require: plugin.Required},
+				{Name: "form", Require: plugin.Optional},
+				{Name: "filing_date", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+				{Name: "acceptance_date_time", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+			},
 		},
 		Columns: []*plugin.Column{
 			{Name: "cik", Type: proto.ColumnType_STRING, Transform: transform.FromField("CIK").Transform(transformCIK), Description: "CIK (Central Index Key) of the filer."},
 			{Name: "accession_number", Type: proto.ColumnType_STRING, Description: "Accession number of the filing."},
-			{Name: "filing_date", Type: proto.ColumnType_STRING, Description: "Filing date of the filing."},
-			{Name: "report_date", Type: proto.ColumnType_STRING, Description: "Report date of the company."},
+			{Name: "filing_date", Type: proto.ColumnType_TIMESTAMP, Description: "Filing date of the filing."},
+			{Name: "report_date", Type: proto.ColumnType_TIMESTAMP, Description: "Report date of the company, e.g. the end of the period covered by a 10-K or 10-Q."},
 			{Name: "acceptance_date_time", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("AcceptanceDateTime"), Description: "Acceptance datetime of the filing."},
 			{Name: "act", Type: proto.ColumnType_STRING, Description: "Act of the filing."},
 			{Name: "form", Type: proto.ColumnType_STRING, Description: "Form of the filing."},
 			{Name: "file_number", Type: proto.ColumnType_STRING, Description: "File number of the filing."},
 			{Name: "film_number", Type: proto.ColumnType_STRING, Description: "Film number of the filing."},
-			{Name: "items", Type: proto.ColumnType_STRING, Description: "Items of the filing."},
-			{Name: "size", Type: proto.ColumnType_STRING, Description: "Size of the filing."},
-			{Name: "is_xbrl", Type: proto.ColumnType_INT, Transform: transform.FromField("IsXBRL"), Description: "Whether or not the filing is in XBRL format."},
-			{Name: "is_inline_xbrl", Type: proto.ColumnType_INT, Transform: transform.FromField("IsInlineXBRL"), Description: "Whether or not the filing is in inline XBRL format."},
+			{Name: "items", Type: proto.ColumnType_JSON, Transform: transform.FromField("Items").Transform(transformItems), Description: "Item codes of the filing, e.g. [\"2.02\", \"9.01\"] for an 8-K."},
+			{Name: "size", Type: proto.ColumnType_INT, Description: "Size of the filing in bytes."},
+			{Name: "is_xbrl", Type: proto.ColumnType_BOOL, Transform: transform.FromField("IsXBRL").Transform(transformIntToBool), Description: "Whether or not the filing is in XBRL format."},
+			{Name: "is_inline_xbrl", Type: proto.ColumnType_BOOL, Transform: transform.FromField("IsInlineXBRL").Transform(transformIntToBool), Description: "Whether or not the filing is in inline XBRL format."},
 			{Name: "primary_document", Type: proto.ColumnType_STRING, Description: "Primary document of the filing."},
 			{Name: "primary_doc_description", Type: proto.ColumnType_STRING, Description: "Primary document description."},
 			{Name: "index_url", Type: proto.ColumnType_STRING, Description: "Index URL of the filing."},
@@ -44,14 +49,12 @@ func tableSecFilings(ctx context.Context) *plugin.Table {
 }
 
 func listSecFilings(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	// TODO: move client init to main() in main.go
 	logger := plugin.Logger(ctx)
-	apiKey := os.Getenv("IEX_API_KEY")
-	logger.Info("IEX API key = ", apiKey)
-	if apiKey == "" {
-		panic("No IEX API Key found")
+	client, err := connect(ctx, d)
+	if err != nil {
+		logger.Error("tableSecFilings.listSecFilings", "connection_error", err)
+		return nil, err
 	}
-	client := edgar.NewClient(apiKey)
 	quals := d.KeyColumnQuals
 	cik := quals["cik"].GetStringValue()
 	filer, err := client.GetSubmissions(cik)
@@ -59,90 +62,317 @@ func listSecFilings(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 		logger.Error("tableSecFilings.listSecFilings", "query_error", err)
 		return nil, err
 	}
-	for idx := range *filer.Filings.Recent.AccessionNumber {
-		filing := edgar.Filing{}
-		filing.CIK = filer.CIK
-		if filer.Filings.Recent.AccessionNumber != nil {
-			filing.AccessionNumber = &(*filer.Filings.Recent.AccessionNumber)[idx]
-			// index_url
-			filing.IndexURL, err = extractIndexURL(filing.CIK, filing.AccessionNumber)
-			if err != nil {
-				panic("Unable to extract valid index URL.")
+	filter := newFilingFilter(d)
+	err = forEachFilingTable(ctx, d, client, filer, filter, func(table *edgar.FilingTable) error {
+		return streamFilingTable(ctx, d, client, filer.CIK, table, filter)
+	})
+	if err != nil {
+		logger.Error("tableSecFilings.listSecFilings", "query_error", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// errStopFilings is returned by a forEachFilingTable callback once it has
+// found every filing it needs, to stop paging through older filings
+var errStopFilings = errors.New("stop listing filings")
+
+// forEachFilingTable :: call fn with the recent filings of a filer, then with
+// each additional file of older filings. Files are paged newest first and
+// only fetched while the query still needs rows from their date range.
+func forEachFilingTable(ctx context.Context, d *plugin.QueryData, client edgar.Client, filer *edgar.SubmissionsSearchResult, filter *filingFilter, fn func(*edgar.FilingTable) error) error {
+	return pageFilingTables(client, filer, filter.earliestFilingDate(), func() bool {
+		return d.QueryStatus.RowsRemaining(ctx) != 0
+	}, fn)
+}
+
+// pageFilingTables :: call fn with the recent filings, then with each older
+// submissions file until more returns false, a file ends before the from date
+// (YYYY-MM-DD, or empty for no lower bound) or fn returns errStopFilings
+func pageFilingTables(client edgar.Client, filer *edgar.SubmissionsSearchResult, from string, more func() bool, fn func(*edgar.FilingTable) error) error {
+	if filer.Filings == nil {
+		return nil
+	}
+	if err := fn(filer.Filings.Recent); err != nil {
+		if errors.Is(err, errStopFilings) {
+			return nil
+		}
+		return err
+	}
+	if filer.Filings.Files == nil {
+		return nil
+	}
+	for _, file := range *filer.Filings.Files {
+		if !more() {
+			return nil
+		}
+		if from != "" && file.FilingTo < from {
+			return nil
+		}
+		filings, err := client.GetSubmissionsFile(file.Name)
+		if err != nil {
+			return fmt.Errorf("unable to get submissions file %s: %w", file.Name, err)
+		}
+		if err := fn(filings); err != nil {
+			if errors.Is(err, errStopFilings) {
+				return nil
 			}
+			return err
 		}
-		if filer.Filings.Recent.FilingDate != nil {
-			filing.FilingDate = &(*filer.Filings.Recent.FilingDate)[idx]
+	}
+	return nil
+}
+
+// streamFilingTable :: convert the column major filings table into one row per
+// filing, streaming only the filings that match the filter
+func streamFilingTable(ctx context.Context, d *plugin.QueryData, client edgar.Client, cik *string, table *edgar.FilingTable, filter *filingFilter) error {
+	if table == nil || table.AccessionNumber == nil {
+		return nil
+	}
+	var err error
+	for idx := range *table.AccessionNumber {
+		filing := edgar.Filing{}
+		filing.CIK = cik
+		filing.AccessionNumber = &(*table.AccessionNumber)[idx]
+		if table.FilingDate != nil {
+			filing.FilingDate = edgar.ParseDate((*table.FilingDate)[idx])
+		}
+		if table.AcceptanceDateTime != nil {
+			filing.AcceptanceDateTime = &(*table.AcceptanceDateTime)[idx]
 		}
-		if filer.Filings.Recent.ReportDate != nil {
-			filing.ReportDate = &(*filer.Filings.Recent.ReportDate)[idx]
+		if table.Form != nil {
+			filing.Form = &(*table.Form)[idx]
 		}
-		if filer.Filings.Recent.AcceptanceDateTime != nil {
-			filing.AcceptanceDateTime = &(*filer.Filings.Recent.AcceptanceDateTime)[idx]
+		if !filter.match(&filing) {
+			continue
 		}
-		if filer.Filings.Recent.Act != nil {
-			filing.Act = &(*filer.Filings.Recent.Act)[idx]
+		// index_url
+		filing.IndexURL, err = extractIndexURL(client, filing.CIK, filing.AccessionNumber)
+		if err != nil {
+			return fmt.Errorf("unable to extract index URL for %s: %w", *filing.AccessionNumber, err)
 		}
-		if filer.Filings.Recent.Form != nil {
-			filing.Form = &(*filer.Filings.Recent.Form)[idx]
+		if table.ReportDate != nil {
+			filing.ReportDate = edgar.ParseDate((*table.ReportDate)[idx])
 		}
-		if filer.Filings.Recent.FileNumber != nil {
-			filing.FileNumber = &(*filer.Filings.Recent.FileNumber)[idx]
+		if table.Act != nil {
+			filing.Act = &(*table.Act)[idx]
 		}
-		if filer.Filings.Recent.FilmNumber != nil {
-			filing.FilmNumber = &(*filer.Filings.Recent.FilmNumber)[idx]
+		if table.FileNumber != nil {
+			filing.FileNumber = &(*table.FileNumber)[idx]
 		}
-		if filer.Filings.Recent.Items != nil {
-			filing.Items = &(*filer.Filings.Recent.Items)[idx]
+		if table.FilmNumber != nil {
+			filing.FilmNumber = &(*table.FilmNumber)[idx]
 		}
-		if filer.Filings.Recent.Size != nil {
-			filing.Size = &(*filer.Filings.Recent.Size)[idx]
+		if table.Items != nil {
+			filing.Items = &(*table.Items)[idx]
 		}
-		if filer.Filings.Recent.IsXBRL != nil {
-			filing.IsXBRL = &(*filer.Filings.Recent.IsXBRL)[idx]
+		if table.Size != nil {
+			filing.Size = &(*table.Size)[idx]
 		}
-		if filer.Filings.Recent.IsInlineXBRL != nil {
-			filing.IsInlineXBRL = &(*filer.Filings.Recent.IsInlineXBRL)[idx]
+		if table.IsXBRL != nil {
+			filing.IsXBRL = &(*table.IsXBRL)[idx]
 		}
-		if filer.Filings.Recent.PrimaryDocument != nil {
-			filing.PrimaryDocument = &(*filer.Filings.Recent.PrimaryDocument)[idx]
-			if filer.Filings.Recent.AccessionNumber != nil {
-				filing.PrimaryDocument, err = extractDocumentUrl(filing.CIK, filing.AccessionNumber, filing.PrimaryDocument)
+		if table.IsInlineXBRL != nil {
+			filing.IsInlineXBRL = &(*table.IsInlineXBRL)[idx]
+		}
+		if table.PrimaryDocument != nil {
+			filing.PrimaryDocument, err = extractDocumentUrl(client, filing.CIK, filing.AccessionNumber, &(*table.PrimaryDocument)[idx])
+			if err != nil {
+				return fmt.Errorf("unable to extract document URL for %s: %w", *filing.AccessionNumber, err)
 			}
 		}
-		if filer.Filings.Recent.PrimaryDocDescription != nil {
-			filing.PrimaryDocDescription = &(*filer.Filings.Recent.PrimaryDocDescription)[idx]
+		if table.PrimaryDocDescription != nil {
+			filing.PrimaryDocDescription = &(*table.PrimaryDocDescription)[idx]
 		}
 		d.StreamListItem(ctx, &filing)
+
+		// stop once the query has all the rows it needs
+		if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			return nil
+		}
 	}
-	return nil, nil
+	return nil
+}
+
+// filingFilter :: the form, filing_date and acceptance_date_time quals of a
+// query, applied in the hydrate so that non-matching filings are never streamed
+type filingFilter struct {
+	forms              map[string]bool
+	filingDates        []*quals.Qual
+	acceptanceDateTime []*quals.Qual
+}
+
+func newFilingFilter(d *plugin.QueryData) *filingFilter {
+	filter := &filingFilter{forms: qualStrings(d, "form")}
+	if d.Quals["filing_date"] != nil {
+		filter.filingDates = d.Quals["filing_date"].Quals
+	}
+	if d.Quals["acceptance_date_time"] != nil {
+		filter.acceptanceDateTime = d.Quals["acceptance_date_time"].Quals
+	}
+	return filter
+}
+
+// qualStrings :: the values of the = and in quals of a string column, or nil
+// if the column is not constrained
+func qualStrings(d *plugin.QueryData, column string) map[string]bool {
+	if d.Quals[column] == nil {
+		return nil
+	}
+	values := map[string]bool{}
+	for _, q := range d.Quals[column].Quals {
+		if list := q.Value.GetListValue(); list != nil {
+			for _, v := range list.Values {
+				values[v.GetStringValue()] = true
+			}
+		} else {
+			values[q.Value.GetStringValue()] = true
+		}
+	}
+	return values
+}
+
+// qualCIKs :: the values of the = and in quals of a cik column keyed by their
+// 10 digit form, or nil if the column is not constrained
+func qualCIKs(d *plugin.QueryData, column string) map[string]string {
+	values := qualStrings(d, column)
+	if values == nil {
+		return nil
+	}
+	ciks := map[string]string{}
+	for cik := range values {
+		ciks[edgar.PadCIK(cik)] = cik
+	}
+	return ciks
+}
+
+// matchCIK :: whether a CIK satisfies the cik quals, with or without leading
+// zeros, and the CIK for its row. That is the value given in the query, as
+// Postgres rechecks the qual on every row, or else the 10 digit form.
+func matchCIK(ciks map[string]string, cik string) (string, bool) {
+	padded := edgar.PadCIK(cik)
+	if ciks == nil {
+		return padded, true
+	}
+	given, ok := ciks[padded]
+	return given, ok
+}
+
+// match :: whether the filing satisfies every qual
+func (f *filingFilter) match(filing *edgar.Filing) bool {
+	if f.forms != nil && (filing.Form == nil || !f.forms[*filing.Form]) {
+		return false
+	}
+	for _, q := range f.filingDates {
+		if filing.FilingDate == nil || !matchTimeQual(q, *filing.FilingDate) {
+			return false
+		}
+	}
+	for _, q := range f.acceptanceDateTime {
+		if filing.AcceptanceDateTime == nil || !matchTimeQual(q, *filing.AcceptanceDateTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// earliestFilingDate :: the earliest filing date, as YYYY-MM-DD, allowed by the
+// quals, or an empty string if there is no lower bound
+func (f *filingFilter) earliestFilingDate() string {
+	var from string
+	// a filing is always accepted on or before its filing date
+	for _, q := range append(f.filingDates, f.acceptanceDateTime...) {
+		switch q.Operator {
+		case "=", ">", ">=":
+			// the lower bound of an in list is its earliest value
+			var earliest string
+			for _, t := range qualTimes(q) {
+				if value := t.UTC().Format(edgar.DateLayout); earliest == "" || value < earliest {
+					earliest = value
+				}
+			}
+			if earliest > from {
+				from = earliest
+			}
+		}
+	}
+	return from
 }
 
-// https://www.sec.gov/Archives/edgar/data/320193/000121465923000970/0001214659-23-000970-index.htm
+// matchTimeQual :: whether a time satisfies a timestamp qual, or any value of
+// an in list that the SDK has not split into separate hydrate calls
+func matchTimeQual(q *quals.Qual, t time.Time) bool {
+	for _, value := range qualTimes(q) {
+		if compareQual(q.Operator, compareTime(t, value)) {
+			return true
+		}
+	}
+	return false
+}
 
-const secDataArchivesUrl string = "https://www.sec.gov/Archives/edgar/data"
+// qualTimes :: the values of a timestamp qual, unpacking an in list
+func qualTimes(q *quals.Qual) []time.Time {
+	if list := q.Value.GetListValue(); list != nil {
+		values := make([]time.Time, 0, len(list.Values))
+		for _, v := range list.Values {
+			values = append(values, v.GetTimestampValue().AsTime())
+		}
+		return values
+	}
+	return []time.Time{q.Value.GetTimestampValue().AsTime()}
+}
+
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// compareQual :: whether the result of comparing a value to a qual value
+// satisfies the qual operator
+func compareQual(operator string, cmp int) bool {
+	switch operator {
+	case "=":
+		return cmp == 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return true
+}
+
+// transformItems :: split the comma separated item codes into a JSON array
+func transformItems(_ context.Context, td *transform.TransformData) (interface{}, error) {
+	items, ok := td.Value.(*string)
+	if !ok || items == nil {
+		return nil, nil
+	}
+	return edgar.SplitItems(*items), nil
+}
 
 // NOTE: the following are custom transformations run outside of the steampipe transformation framework and during the actual call to the HydrateFunction
 
-func extractIndexURL(cik, accessionNumber *string) (*string, error) {
-	compactAccessionNumber := strings.Replace(*accessionNumber, "-", "", -1)
-	cikInt, err := strconv.ParseInt(*cik, 10, 64)
+func extractIndexURL(client edgar.Client, cik, accessionNumber *string) (*string, error) {
+	indexURL, err := client.FilingIndexURL(*cik, *accessionNumber)
 	if err != nil {
 		return nil, err
 	}
-
-	indexURL := strings.Join([]string{secDataArchivesUrl, fmt.Sprint(cikInt), compactAccessionNumber, *accessionNumber}, "/") + "-index.htm"
-
 	return &indexURL, nil
 }
 
-func extractDocumentUrl(cik, accessionNumber, primaryDocument *string) (*string, error) {
-	compactAccessionNumber := strings.Replace(*accessionNumber, "-", "", -1)
-	cikInt, err := strconv.ParseInt(*cik, 10, 64)
+func extractDocumentUrl(client edgar.Client, cik, accessionNumber, primaryDocument *string) (*string, error) {
+	documentURL, err := client.FilingDocumentURL(*cik, *accessionNumber, *primaryDocument)
 	if err != nil {
 		return nil, err
 	}
-
-	indexURL := strings.Join([]string{secDataArchivesUrl, fmt.Sprint(cikInt), compactAccessionNumber, *primaryDocument}, "/")
-
-	return &indexURL, nil
+	return &documentURL, nil
 }
@@ -0,0 +1,289 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/quals"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse(edgar.DateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func timeValue(t time.Time) *proto.QualValue {
+	return &proto.QualValue{Value: &proto.QualValue_TimestampValue{TimestampValue: timestamppb.New(t)}}
+}
+
+// timeQual returns a qual on a timestamp column, with an in list if more than
+// one value is given
+func timeQual(column, operator string, values ...time.Time) *quals.Qual {
+	if len(values) == 1 {
+		return &quals.Qual{Column: column, Operator: operator, Value: timeValue(values[0])}
+	}
+	list := &proto.QualValueList{}
+	for _, v := range values {
+		list.Values = append(list.Values, timeValue(v))
+	}
+	return &quals.Qual{Column: column, Operator: operator, Value: &proto.QualValue{Value: &proto.QualValue_ListValue{ListValue: list}}}
+}
+
+func testFiling(form, filingDate string, accepted time.Time) *edgar.Filing {
+	return &edgar.Filing{Form: &form, FilingDate: edgar.ParseDate(filingDate), AcceptanceDateTime: &accepted}
+}
+
+// cikQueryData returns the query data of a query with cik = or in quals
+func cikQueryData(values ...string) *plugin.QueryData {
+	qual := &quals.Qual{Column: "cik", Operator: "="}
+	if len(values) == 1 {
+		qual.Value = &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: values[0]}}
+	} else {
+		list := &proto.QualValueList{}
+		for _, v := range values {
+			list.Values = append(list.Values, &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: v}})
+		}
+		qual.Value = &proto.QualValue{Value: &proto.QualValue_ListValue{ListValue: list}}
+	}
+	return &plugin.QueryData{Quals: plugin.KeyColumnQualMap{"cik": {Name: "cik", Quals: quals.QualSlice{qual}}}}
+}
+
+// TestMatchCIK returns rows with the cik given in the query, padded or not,
+// so that Postgres does not filter them out when it rechecks the qual
+func TestMatchCIK(t *testing.T) {
+	require.Nil(t, qualCIKs(&plugin.QueryData{}, "cik"))
+	cik, ok := matchCIK(nil, "320193")
+	require.True(t, ok)
+	require.Equal(t, "0000320193", cik)
+
+	ciks := qualCIKs(cikQueryData("320193"), "cik")
+	for _, entry := range []string{"320193", "0000320193"} {
+		cik, ok = matchCIK(ciks, entry)
+		require.True(t, ok)
+		require.Equal(t, "320193", cik)
+	}
+
+	ciks = qualCIKs(cikQueryData("0000320193", "789019"), "cik")
+	cik, ok = matchCIK(ciks, "320193")
+	require.True(t, ok)
+	require.Equal(t, "0000320193", cik)
+	cik, ok = matchCIK(ciks, "0000789019")
+	require.True(t, ok)
+	require.Equal(t, "789019", cik)
+	_, ok = matchCIK(ciks, "1018724")
+	require.False(t, ok)
+}
+
+func TestCompareQual(t *testing.T) {
+	for _, tc := range []struct {
+		operator string
+		want     [3]bool // for a value less than, equal to and greater than the qual
+	}{
+		{"=", [3]bool{false, true, false}},
+		{">", [3]bool{false, false, true}},
+		{">=", [3]bool{false, true, true}},
+		{"<", [3]bool{true, false, false}},
+		{"<=", [3]bool{true, true, false}},
+		{"<>", [3]bool{true, true, true}},
+	} {
+		for i, cmp := range []int{-1, 0, 1} {
+			require.Equal(t, tc.want[i], compareQual(tc.operator, cmp), "%s %d", tc.operator, cmp)
+		}
+	}
+	require.Equal(t, -1, compareTime(date("2023-01-01"), date("2023-01-02")))
+	require.Equal(t, 0, compareTime(date("2023-01-01"), date("2023-01-01")))
+	require.Equal(t, 1, compareTime(date("2023-01-02"), date("2023-01-01")))
+}
+
+func TestFilingFilterMatch(t *testing.T) {
+	annual := testFiling("10-K", "2023-02-03", date("2023-02-02").Add(18*time.Hour))
+	quarterly := testFiling("10-Q", "2023-05-05", date("2023-05-05").Add(16*time.Hour))
+	undated := &edgar.Filing{Form: edgar.Ptr("8-K")}
+
+	// no quals match everything
+	filter := &filingFilter{}
+	require.True(t, filter.match(annual))
+	require.True(t, filter.match(undated))
+
+	filter = &filingFilter{forms: map[string]bool{"10-K": true}}
+	require.True(t, filter.match(annual))
+	require.False(t, filter.match(quarterly))
+
+	// a range on filing_date
+	filter = &filingFilter{filingDates: []*quals.Qual{
+		timeQual("filing_date", ">=", date("2023-02-03")),
+		timeQual("filing_date", "<", date("2023-05-05")),
+	}}
+	require.True(t, filter.match(annual))
+	require.False(t, filter.match(quarterly))
+	require.False(t, filter.match(undated))
+
+	// an in list on filing_date, as passed when the SDK does not split it
+	filter = &filingFilter{filingDates: []*quals.Qual{timeQual("filing_date", "=", date("2023-01-01"), date("2023-05-05"))}}
+	require.False(t, filter.match(annual))
+	require.True(t, filter.match(quarterly))
+
+	// an in list on acceptance_date_time
+	filter = &filingFilter{acceptanceDateTime: []*quals.Qual{timeQual("acceptance_date_time", "=", date("2023-05-05"), *quarterly.AcceptanceDateTime)}}
+	require.False(t, filter.match(annual))
+	require.True(t, filter.match(quarterly))
+
+	filter = &filingFilter{acceptanceDateTime: []*quals.Qual{timeQual("acceptance_date_time", ">", date("2023-03-01"))}}
+	require.False(t, filter.match(annual))
+	require.True(t, filter.match(quarterly))
+}
+
+func TestFilingFilterEarliestFilingDate(t *testing.T) {
+	require.Equal(t, "", (&filingFilter{}).earliestFilingDate())
+
+	// upper bounds do not limit how far back filings go
+	filter := &filingFilter{filingDates: []*quals.Qual{timeQual("filing_date", "<", date("2023-01-01"))}}
+	require.Equal(t, "", filter.earliestFilingDate())
+
+	// the latest lower bound wins, from either column
+	filter = &filingFilter{
+		filingDates:        []*quals.Qual{timeQual("filing_date", ">=", date("2020-01-01"))},
+		acceptanceDateTime: []*quals.Qual{timeQual("acceptance_date_time", ">", date("2021-06-30"))},
+	}
+	require.Equal(t, "2021-06-30", filter.earliestFilingDate())
+
+	// an in list is bounded by its earliest value
+	filter = &filingFilter{filingDates: []*quals.Qual{timeQual("filing_date", "=", date("2022-03-01"), date("2019-07-15"), date("2021-01-04"))}}
+	require.Equal(t, "2019-07-15", filter.earliestFilingDate())
+}
+
+// fakeSubmissionsClient serves older submissions files by name and records
+// which ones were fetched
+type fakeSubmissionsClient struct {
+	edgar.Client
+	files   map[string]*edgar.FilingTable
+	fetched []string
+}
+
+func (c *fakeSubmissionsClient) GetSubmissionsFile(name string) (*edgar.FilingTable, error) {
+	c.fetched = append(c.fetched, name)
+	table, ok := c.files[name]
+	if !ok {
+		return nil, fmt.Errorf("no such file %s", name)
+	}
+	return table, nil
+}
+
+func testFilingTable(accessionNumbers ...string) *edgar.FilingTable {
+	return &edgar.FilingTable{AccessionNumber: &accessionNumbers}
+}
+
+// testFiler returns a filer with recent filings and three older files, newest
+// first as listed by SEC
+func testFiler() (*edgar.SubmissionsSearchResult, *fakeSubmissionsClient) {
+	filer := &edgar.SubmissionsSearchResult{Filings: &edgar.FilingRecord{
+		Recent: testFilingTable("recent"),
+		Files: &[]edgar.FilingsFile{
+			{Name: "CIK0000320193-submissions-001.json", FilingFrom: "2015-01-02", FilingTo: "2018-12-28"},
+			{Name: "CIK0000320193-submissions-002.json", FilingFrom: "2005-01-03", FilingTo: "2014-12-30"},
+			{Name: "CIK0000320193-submissions-003.json", FilingFrom: "1994-01-26", FilingTo: "2004-12-29"},
+		},
+	}}
+	client := &fakeSubmissionsClient{files: map[string]*edgar.FilingTable{
+		"CIK0000320193-submissions-001.json": testFilingTable("001"),
+		"CIK0000320193-submissions-002.json": testFilingTable("002"),
+		"CIK0000320193-submissions-003.json": testFilingTable("003"),
+	}}
+	return filer, client
+}
+
+// pageTestFilings pages through the test filer and returns the accession
+// numbers seen by the callback, which fails with stopAt's error if set
+func pageTestFilings(from string, rows int, stopAt string, stopErr error) ([]string, []string, error) {
+	filer, client := testFiler()
+	seen := []string{}
+	more := func() bool { return len(seen) < rows }
+	err := pageFilingTables(client, filer, from, more, func(table *edgar.FilingTable) error {
+		accessionNumber := (*table.AccessionNumber)[0]
+		seen = append(seen, accessionNumber)
+		if accessionNumber == stopAt {
+			return stopErr
+		}
+		return nil
+	})
+	return seen, client.fetched, err
+}
+
+func TestPageFilingTables(t *testing.T) {
+	// without a lower bound every file is fetched, newest first
+	seen, fetched, err := pageTestFilings("", 100, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"recent", "001", "002", "003"}, seen)
+	require.Len(t, fetched, 3)
+
+	// files that end before the lower bound are never fetched
+	seen, fetched, err = pageTestFilings("2016-06-01", 100, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"recent", "001"}, seen)
+	require.Equal(t, []string{"CIK0000320193-submissions-001.json"}, fetched)
+
+	seen, fetched, err = pageTestFilings("2019-01-01", 100, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"recent"}, seen)
+	require.Empty(t, fetched)
+
+	// paging stops once the query has all its rows
+	seen, fetched, err = pageTestFilings("", 1, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"recent"}, seen)
+	require.Empty(t, fetched)
+
+	seen, _, err = pageTestFilings("", 2, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"recent", "001"}, seen)
+
+	// errStopFilings ends paging without an error
+	seen, _, err = pageTestFilings("", 100, "recent", errStopFilings)
+	require.NoError(t, err)
+	require.Equal(t, []string{"recent"}, seen)
+
+	seen, _, err = pageTestFilings("", 100, "002", errStopFilings)
+	require.NoError(t, err)
+	require.Equal(t, []string{"recent", "001", "002"}, seen)
+
+	// other callback errors are returned
+	callbackErr := errors.New("callback failed")
+	_, _, err = pageTestFilings("", 100, "001", callbackErr)
+	require.ErrorIs(t, err, callbackErr)
+}
+
+func TestPageFilingTablesErrors(t *testing.T) {
+	filer, client := testFiler()
+	delete(client.files, "CIK0000320193-submissions-002.json")
+	err := pageFilingTables(client, filer, "", func() bool { return true }, func(*edgar.FilingTable) error { return nil })
+	require.ErrorContains(t, err, "unable to get submissions file CIK0000320193-submissions-002.json")
+
+	// a filer without filings has nothing to page
+	require.NoError(t, pageFilingTables(client, &edgar.SubmissionsSearchResult{}, "", func() bool { return true }, func(*edgar.FilingTable) error {
+		t.Fatal("unexpected filings")
+		return nil
+	}))
+}
+
+func TestPageFilingTablesDateQuals(t *testing.T) {
+	// the filing_date lower bound of a query decides which files are fetched
+	filter := &filingFilter{filingDates: []*quals.Qual{
+		timeQual("filing_date", ">=", date("2010-01-01")),
+		timeQual("filing_date", "<", date("2012-01-01")),
+	}}
+	filer, client := testFiler()
+	err := pageFilingTables(client, filer, filter.earliestFilingDate(), func() bool { return true }, func(*edgar.FilingTable) error { return nil })
+	require.NoError(t, err)
+	require.Equal(t, []string{"CIK0000320193-submissions-001.json", "CIK0000320193-submissions-002.json"}, client.fetched)
+}
@@ -0,0 +1,113 @@
+package finance
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func tableSecFrame(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "sec_frame",
+		Description: "Value of an XBRL concept for every company that reported it for a calendar period, from the SEC frames API.",
+		List: &plugin.ListConfig{
+			Hydrate: listSecFrame,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "taxonomy", Require: plugin.Required},
+				{Name: "tag", Require: plugin.Required},
+				{Name: "unit", Require: plugin.Required},
+				{Name: "period", Require: plugin.Required},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError,
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "taxonomy", Type: proto.ColumnType_STRING, Description: "XBRL taxonomy of the concept, e.g. us-gaap, ifrs-full, dei."},
+			{Name: "tag", Type: proto.ColumnType_STRING, Description: "XBRL concept (tag), e.g. Revenues."},
+			{Name: "unit", Type: proto.ColumnType_STRING, Description: "Unit of measure of the value, e.g. USD, shares, USD-per-shares."},
+			{Name: "period", Type: proto.ColumnType_STRING, Description: "Calendar period of the frame, e.g. CY2023 (annual), CY2023Q4 (quarterly) or CY2023Q4I (instant)."},
+			{Name: "label", Type: proto.ColumnType_STRING, Description: "Human readable label of the concept."},
+			{Name: "description", Type: proto.ColumnType_STRING, Description: "Description of the concept."},
+			{Name: "cik", Type: proto.ColumnType_STRING, Transform: transform.FromField("CIK"), Description: "CIK (Central Index Key) of the filer."},
+			{Name: "entity_name", Type: proto.ColumnType_STRING, Description: "Name of the filer."},
+			{Name: "loc", Type: proto.ColumnType_STRING, Description: "Location of the filer's business address, e.g. US-CA."},
+			{Name: "accn", Type: proto.ColumnType_STRING, Description: "Accession number of the filing that reported the value."},
+			{Name: "val", Type: proto.ColumnType_DOUBLE, Description: "Reported value."},
+			{Name: "start", Type: proto.ColumnType_TIMESTAMP, Description: "Start of the reporting period, null for instant frames."},
+			{Name: "end", Type: proto.ColumnType_TIMESTAMP, Description: "End of the reporting period, or the instant of the value."},
+		},
+	}
+}
+
+// secFrameValue is the value reported by a single filer within a frame
+type secFrameValue struct {
+	Taxonomy    string
+	Tag         string
+	Unit        string
+	Period      string
+	Label       *string
+	Description *string
+	CIK         *string
+	EntityName  *string
+	Loc         *string
+	Accn        *string
+	Val         *float64
+	Start       *time.Time
+	End         *time.Time
+}
+
+func listSecFrame(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	client, err := connect(ctx, d)
+	if err != nil {
+		logger.Error("tableSecFrame.listSecFrame", "connection_error", err)
+		return nil, err
+	}
+	quals := d.KeyColumnQuals
+	taxonomy := quals["taxonomy"].GetStringValue()
+	tag := quals["tag"].GetStringValue()
+	unit := quals["unit"].GetStringValue()
+	period := quals["period"].GetStringValue()
+
+	frame, err := client.GetFrame(taxonomy, tag, unit, period)
+	if err != nil {
+		logger.Error("tableSecFrame.listSecFrame", "query_error", err)
+		return nil, err
+	}
+
+	for _, v := range frame.Data {
+		row := &secFrameValue{
+			Taxonomy:    taxonomy,
+			Tag:         tag,
+			Unit:        unit,
+			Period:      period,
+			Label:       frame.Label,
+			Description: frame.Description,
+			EntityName:  v.EntityName,
+			Loc:         v.Loc,
+			Accn:        v.Accn,
+			Val:         v.Val,
+		}
+		if v.CIK != nil {
+			row.CIK = edgar.Ptr(edgar.PadCIK(strconv.FormatInt(*v.CIK, 10)))
+		}
+		if v.Start != nil {
+			row.Start = edgar.ParseDate(*v.Start)
+		}
+		if v.End != nil {
+			row.End = edgar.ParseDate(*v.End)
+		}
+		d.StreamListItem(ctx, row)
+		if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,160 @@
+package finance
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func tableSecFullTextSearch(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "sec_full_text_search",
+		Description: "Search the text of SEC Edgar filings made since 2001, one row per matching document.",
+		List: &plugin.ListConfig{
+			Hydrate: listSecFullTextSearch,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "query", Require: plugin.Required},
+				{Name: "form", Require: plugin.Optional},
+				{Name: "cik", Require: plugin.Optional},
+				{Name: "filing_date", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "query", Type: proto.ColumnType_STRING, Transform: transform.FromQual("query"), Description: "Search query, e.g. \"going concern\" in double quotes for an exact phrase."},
+			{Name: "accession_number", Type: proto.ColumnType_STRING, Description: "Accession number of the filing."},
+			{Name: "cik", Type: proto.ColumnType_STRING, Transform: transform.FromField("CIK"), Description: "CIK (Central Index Key) of the filer. For filings with several filers, the one matching the cik qual, or else the first."},
+			{Name: "ciks", Type: proto.ColumnType_JSON, Transform: transform.FromField("CIKs"), Description: "CIKs of every filer of the filing."},
+			{Name: "display_names", Type: proto.ColumnType_JSON, Description: "Names, tickers and CIKs of the filers, e.g. [\"Apple Inc.  (AAPL)  (CIK 0000320193)\"]."},
+			{Name: "form", Type: proto.ColumnType_STRING, Description: "Form of the filing, e.g. 10-K."},
+			{Name: "root_forms", Type: proto.ColumnType_JSON, Description: "Base forms of the filing, e.g. [\"10-K\"] for a 10-K/A."},
+			{Name: "filing_date", Type: proto.ColumnType_TIMESTAMP, Description: "Filing date of the filing."},
+			{Name: "period_ending", Type: proto.ColumnType_TIMESTAMP, Description: "End of the period the filing reports on."},
+			{Name: "document_name", Type: proto.ColumnType_STRING, Description: "File name of the matching document within the filing."},
+			{Name: "document_type", Type: proto.ColumnType_STRING, Description: "Type of the matching document, e.g. 10-K or EX-99.1."},
+			{Name: "document_description", Type: proto.ColumnType_STRING, Description: "Description of the matching document."},
+			{Name: "document_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("DocumentURL"), Description: "URL of the matching document."},
+			{Name: "index_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("IndexURL"), Description: "Index URL of the filing."},
+			{Name: "items", Type: proto.ColumnType_JSON, Description: "Item codes of the filing, e.g. [\"2.02\", \"9.01\"] for an 8-K."},
+			{Name: "score", Type: proto.ColumnType_DOUBLE, Description: "Relevance score of the document for the query."},
+			{Name: "snippet", Type: proto.ColumnType_STRING, Transform: transform.FromField("Snippet").Transform(transform.NullIfZeroValue), Description: "Highlighted extracts of the document around the matches, when returned by the search."},
+		},
+	}
+}
+
+// secFullTextSearchHit is a document matching a full text search
+type secFullTextSearchHit struct {
+	AccessionNumber     string
+	CIK                 string
+	CIKs                []string
+	DisplayNames        []string
+	Form                string
+	RootForms           []string
+	FilingDate          *time.Time
+	PeriodEnding        *time.Time
+	DocumentName        string
+	DocumentType        string
+	DocumentDescription *string
+	DocumentURL         string
+	IndexURL            string
+	Items               []string
+	Score               float64
+	Snippet             string
+}
+
+func listSecFullTextSearch(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	client, err := connect(ctx, d)
+	if err != nil {
+		logger.Error("tableSecFullTextSearch.listSecFullTextSearch", "connection_error", err)
+		return nil, err
+	}
+
+	query := edgar.FullTextQuery{Query: d.KeyColumnQuals["query"].GetStringValue()}
+	query.Forms = sortedKeys(qualStrings(d, "form"))
+	query.CIKs = sortedKeys(qualStrings(d, "cik"))
+	ciks := qualCIKs(d, "cik")
+	from, to := filingDateBounds(d)
+	if !from.IsZero() {
+		query.StartDate = &from
+	}
+	if !to.IsZero() {
+		query.EndDate = &to
+	}
+
+	for query.From < edgar.FullTextSearchMaxHits {
+		result, err := client.SearchFullText(query)
+		if err != nil {
+			logger.Error("tableSecFullTextSearch.listSecFullTextSearch", "query_error", err)
+			return nil, err
+		}
+		for _, hit := range result.Hits.Hits {
+			row, err := newSecFullTextSearchHit(client, hit, ciks)
+			if err != nil {
+				logger.Error("tableSecFullTextSearch.listSecFullTextSearch", "query_error", err)
+				return nil, err
+			}
+			d.StreamListItem(ctx, row)
+
+			// stop once the query has all the rows it needs
+			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+		query.From += len(result.Hits.Hits)
+		if len(result.Hits.Hits) < edgar.FullTextSearchPageSize || query.From >= result.Hits.Total.Value {
+			break
+		}
+	}
+	return nil, nil
+}
+
+// newSecFullTextSearchHit :: flatten a search hit into a row, picking the
+// filer that matches the cik quals for filings with several filers
+func newSecFullTextSearchHit(client edgar.Client, hit edgar.FullTextSearchHit, ciks map[string]string) (*secFullTextSearchHit, error) {
+	source := hit.Source
+	row := &secFullTextSearchHit{
+		AccessionNumber:     source.AccessionNumber,
+		CIKs:                source.CIKs,
+		DisplayNames:        source.DisplayNames,
+		Form:                source.Form,
+		RootForms:           source.RootForms,
+		FilingDate:          edgar.ParseDate(source.FileDate),
+		PeriodEnding:        edgar.ParseDate(source.PeriodEnding),
+		DocumentName:        hit.DocumentName(),
+		DocumentType:        source.FileType,
+		DocumentDescription: source.FileDescription,
+		Items:               source.Items,
+		Score:               hit.Score,
+		Snippet:             strings.Join(hit.Snippets(), " ... "),
+	}
+	for _, cik := range source.CIKs {
+		if match, ok := matchCIK(ciks, cik); ok {
+			row.CIK = match
+			break
+		}
+		if row.CIK == "" {
+			row.CIK = edgar.PadCIK(cik)
+		}
+	}
+	if row.CIK == "" || row.AccessionNumber == "" {
+		return row, nil
+	}
+
+	indexURL, err := client.FilingIndexURL(row.CIK, row.AccessionNumber)
+	if err != nil {
+		return nil, err
+	}
+	row.IndexURL = indexURL
+	if row.DocumentName != "" {
+		if row.DocumentURL, err = client.FilingDocumentURL(row.CIK, row.AccessionNumber, row.DocumentName); err != nil {
+			return nil, err
+		}
+	}
+	return row, nil
+}
@@ -0,0 +1,35 @@
+package finance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+)
+
+// TestFullTextSearchHitCIK picks the filer matching the cik qual, returning
+// the cik as given in the query so Postgres keeps the row
+func TestFullTextSearchHitCIK(t *testing.T) {
+	client := edgar.NewClient(edgar.Config{SECArchivesBaseURL: "https://www.sec.gov/Archives"})
+	hit := edgar.FullTextSearchHit{ID: "0001193125-23-001234:d123456dex991.htm"}
+	hit.Source.AccessionNumber = "0001193125-23-001234"
+	hit.Source.CIKs = []string{"0000789019", "0000320193"}
+
+	row, err := newSecFullTextSearchHit(client, hit, nil)
+	require.NoError(t, err)
+	require.Equal(t, "0000789019", row.CIK)
+	require.Equal(t, "https://www.sec.gov/Archives/edgar/data/789019/000119312523001234/0001193125-23-001234-index.htm", row.IndexURL)
+
+	for _, cik := range []string{"320193", "0000320193"} {
+		row, err = newSecFullTextSearchHit(client, hit, qualCIKs(cikQueryData(cik), "cik"))
+		require.NoError(t, err)
+		require.Equal(t, cik, row.CIK)
+		require.Equal(t, "https://www.sec.gov/Archives/edgar/data/320193/000119312523001234/d123456dex991.htm", row.DocumentURL)
+	}
+
+	// a hit matching none of the ciks falls back to its first filer
+	row, err = newSecFullTextSearchHit(client, hit, qualCIKs(cikQueryData("1018724"), "cik"))
+	require.NoError(t, err)
+	require.Equal(t, "0000789019", row.CIK)
+}
@@ -0,0 +1,206 @@
+package finance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
+
+	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v4/plugin/transform"
+)
+
+func tableSecInsiderTransaction(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "sec_insider_transaction",
+		Description: "Insider transactions and holdings reported to the SEC on Forms 3, 4 and 5, one row per transaction.",
+		List: &plugin.ListConfig{
+			Hydrate: listSecInsiderTransactions,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "cik", Require: plugin.Required},
+				{Name: "form", Require: plugin.Optional},
+				{Name: "filing_date", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: isNotFoundError,
+			},
+		},
+		Columns: []*plugin.Column{
+			{Name: "cik", Type: proto.ColumnType_STRING, Transform: transform.FromField("CIK"), Description: "CIK (Central Index Key) of the issuer."},
+			{Name: "issuer_name", Type: proto.ColumnType_STRING, Description: "Name of the issuer."},
+			{Name: "issuer_trading_symbol", Type: proto.ColumnType_STRING, Description: "Trading symbol of the issuer."},
+			{Name: "accession_number", Type: proto.ColumnType_STRING, Description: "Accession number of the filing."},
+			{Name: "form", Type: proto.ColumnType_STRING, Description: "Form of the filing, e.g. 4 or 4/A."},
+			{Name: "filing_date", Type: proto.ColumnType_TIMESTAMP, Description: "Filing date of the filing."},
+			{Name: "period_of_report", Type: proto.ColumnType_TIMESTAMP, Description: "Date of the earliest transaction reported on the form."},
+			{Name: "reporting_owner_cik", Type: proto.ColumnType_STRING, Transform: transform.FromField("ReportingOwnerCIK"), Description: "CIK of the reporting owner. For joint filings this is the first owner, see reporting_owners."},
+			{Name: "reporting_owner_name", Type: proto.ColumnType_STRING, Description: "Name of the reporting owner."},
+			{Name: "is_director", Type: proto.ColumnType_BOOL, Description: "True if the reporting owner is a director of the issuer."},
+			{Name: "is_officer", Type: proto.ColumnType_BOOL, Description: "True if the reporting owner is an officer of the issuer."},
+			{Name: "officer_title", Type: proto.ColumnType_STRING, Transform: transform.FromField("OfficerTitle").Transform(transform.NullIfZeroValue), Description: "Title of the reporting owner, if an officer."},
+			{Name: "is_ten_percent_owner", Type: proto.ColumnType_BOOL, Description: "True if the reporting owner holds more than 10% of a class of the issuer's securities."},
+			{Name: "is_other", Type: proto.ColumnType_BOOL, Description: "True if the reporting owner has another relationship with the issuer, see other_text."},
+			{Name: "other_text", Type: proto.ColumnType_STRING, Transform: transform.FromField("OtherText").Transform(transform.NullIfZeroValue), Description: "Description of the other relationship."},
+			{Name: "reporting_owners", Type: proto.ColumnType_JSON, Description: "All reporting owners of the filing and their relationship to the issuer."},
+			{Name: "is_derivative", Type: proto.ColumnType_BOOL, Description: "True for derivative securities such as options and restricted stock units."},
+			{Name: "is_holding", Type: proto.ColumnType_BOOL, Description: "True for holdings, e.g. on Form 3, which have no transaction fields."},
+			{Name: "security_title", Type: proto.ColumnType_STRING, Description: "Title of the security, e.g. Common Stock."},
+			{Name: "transaction_date", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("TransactionDate").Transform(transformDate), Description: "Date of the transaction."},
+			{Name: "transaction_code", Type: proto.ColumnType_STRING, Transform: transform.FromField("TransactionCode").Transform(transform.NullIfZeroValue), Description: "Transaction code, e.g. P (open market purchase), S (open market sale), A (grant), M (option exercise), F (tax withholding)."},
+			{Name: "equity_swap_involved", Type: proto.ColumnType_BOOL, Description: "True if the transaction involved an equity swap."},
+			{Name: "acquired_disposed_code", Type: proto.ColumnType_STRING, Transform: transform.FromField("AcquiredDisposedCode").Transform(transform.NullIfZeroValue), Description: "A if the securities were acquired, D if disposed of."},
+			{Name: "shares", Type: proto.ColumnType_DOUBLE, Description: "Number of securities acquired or disposed of."},
+			{Name: "price_per_share", Type: proto.ColumnType_DOUBLE, Description: "Price per security of the transaction."},
+			{Name: "shares_owned_following_transaction", Type: proto.ColumnType_DOUBLE, Description: "Securities owned by the reporting owner after the transaction, or the holding for holdings."},
+			{Name: "direct_or_indirect_ownership", Type: proto.ColumnType_STRING, Transform: transform.FromField("DirectOrIndirectOwnership").Transform(transform.NullIfZeroValue), Description: "D if owned directly, I if owned indirectly."},
+			{Name: "nature_of_ownership", Type: proto.ColumnType_STRING, Transform: transform.FromField("NatureOfOwnership").Transform(transform.NullIfZeroValue), Description: "Nature of indirect ownership, e.g. By Trust."},
+			{Name: "conversion_or_exercise_price", Type: proto.ColumnType_DOUBLE, Description: "Conversion or exercise price of a derivative security."},
+			{Name: "exercise_date", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("ExerciseDate").Transform(transformDate), Description: "Date a derivative security becomes exercisable."},
+			{Name: "expiration_date", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("ExpirationDate").Transform(transformDate), Description: "Expiration date of a derivative security."},
+			{Name: "underlying_security_title", Type: proto.ColumnType_STRING, Transform: transform.FromField("UnderlyingSecurityTitle").Transform(transform.NullIfZeroValue), Description: "Title of the security underlying a derivative security."},
+			{Name: "underlying_security_shares", Type: proto.ColumnType_DOUBLE, Description: "Number of underlying securities of a derivative security."},
+			{Name: "document_url", Type: proto.ColumnType_STRING, Transform: transform.FromField("DocumentURL"), Description: "URL of the ownership XML document."},
+		},
+	}
+}
+
+// secInsiderTransaction is a transaction or holding of an ownership document,
+// with the filing and reporting owner it belongs to
+type secInsiderTransaction struct {
+	edgar.OwnershipTransaction
+	CIK                 string
+	IssuerName          string
+	IssuerTradingSymbol string
+	AccessionNumber     string
+	Form                string
+	FilingDate          *time.Time
+	PeriodOfReport      *time.Time
+	ReportingOwnerCIK   string
+	ReportingOwnerName  string
+	IsDirector          bool
+	IsOfficer           bool
+	OfficerTitle        string
+	IsTenPercentOwner   bool
+	IsOther             bool
+	OtherText           string
+	ReportingOwners     []edgar.ReportingOwner
+	IsDerivative        bool
+	IsHolding           bool
+	EquitySwapInvolved  bool
+	DocumentURL         string
+}
+
+func listSecInsiderTransactions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	client, err := connect(ctx, d)
+	if err != nil {
+		logger.Error("tableSecInsiderTransaction.listSecInsiderTransactions", "connection_error", err)
+		return nil, err
+	}
+	quals := d.KeyColumnQuals
+	cik := quals["cik"].GetStringValue()
+	filer, err := client.GetSubmissions(cik)
+	if err != nil {
+		logger.Error("tableSecInsiderTransaction.listSecInsiderTransactions", "query_error", err)
+		return nil, err
+	}
+	if filer.CIK == nil {
+		return nil, nil
+	}
+	filter := newFilingFilter(d)
+	err = forEachFilingTable(ctx, d, client, filer, filter, func(table *edgar.FilingTable) error {
+		return streamInsiderTransactions(ctx, d, client, *filer.CIK, table, filter)
+	})
+	if err != nil {
+		logger.Error("tableSecInsiderTransaction.listSecInsiderTransactions", "query_error", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// streamInsiderTransactions :: download the ownership document of every Form
+// 3, 4 or 5 in the filings table that matches the filter, and stream its
+// transactions and holdings
+func streamInsiderTransactions(ctx context.Context, d *plugin.QueryData, client edgar.Client, cik string, table *edgar.FilingTable, filter *filingFilter) error {
+	if table == nil || table.AccessionNumber == nil || table.Form == nil || table.PrimaryDocument == nil {
+		return nil
+	}
+	for idx, accessionNumber := range *table.AccessionNumber {
+		form := (*table.Form)[idx]
+		if !edgar.OwnershipForms[form] {
+			continue
+		}
+		filing := edgar.Filing{AccessionNumber: &(*table.AccessionNumber)[idx], Form: &form}
+		if table.FilingDate != nil {
+			filing.FilingDate = edgar.ParseDate((*table.FilingDate)[idx])
+		}
+		if !filter.match(&filing) {
+			continue
+		}
+
+		// a missing or malformed document only loses that filing's rows
+		doc, err := client.GetOwnershipDocument(cik, accessionNumber, (*table.PrimaryDocument)[idx])
+		if errors.Is(err, edgar.ErrNoOwnershipDocument) || errors.Is(err, edgar.ErrInvalidOwnershipDocument) || isNotFoundError(ctx, d, nil, err) {
+			plugin.Logger(ctx).Warn("tableSecInsiderTransaction.streamInsiderTransactions", "skipped_filing", accessionNumber, "error", err)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("unable to get ownership document of %s: %w", accessionNumber, err)
+		}
+		issuerCIK := doc.IssuerCIK
+		if issuerCIK == "" {
+			issuerCIK = cik
+		}
+		base := secInsiderTransaction{
+			CIK:                 edgar.PadCIK(issuerCIK),
+			IssuerName:          doc.IssuerName,
+			IssuerTradingSymbol: doc.IssuerTradingSymbol,
+			AccessionNumber:     accessionNumber,
+			Form:                form,
+			FilingDate:          filing.FilingDate,
+			PeriodOfReport:      edgar.ParseDate(doc.PeriodOfReport),
+			ReportingOwners:     doc.ReportingOwners,
+			DocumentURL:         doc.URL,
+		}
+		if len(doc.ReportingOwners) > 0 {
+			owner := doc.ReportingOwners[0]
+			base.ReportingOwnerCIK = edgar.PadCIK(owner.CIK)
+			base.ReportingOwnerName = owner.Name
+			base.IsDirector = bool(owner.IsDirector)
+			base.IsOfficer = bool(owner.IsOfficer)
+			base.OfficerTitle = owner.OfficerTitle
+			base.IsTenPercentOwner = bool(owner.IsTenPercentOwner)
+			base.IsOther = bool(owner.IsOther)
+			base.OtherText = owner.OtherText
+		}
+
+		for _, group := range []struct {
+			transactions []edgar.OwnershipTransaction
+			derivative   bool
+			holding      bool
+		}{
+			{doc.NonDerivativeTransactions, false, false},
+			{doc.NonDerivativeHoldings, false, true},
+			{doc.DerivativeTransactions, true, false},
+			{doc.DerivativeHoldings, true, true},
+		} {
+			for _, transaction := range group.transactions {
+				row := base
+				row.OwnershipTransaction = transaction
+				row.IsDerivative = group.derivative
+				row.IsHolding = group.holding
+				row.EquitySwapInvolved = bool(transaction.EquitySwapInvolved)
+				d.StreamListItem(ctx, &row)
+			}
+		}
+
+		// stop once the query has all the rows it needs
+		if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			return nil
+		}
+	}
+	return nil
+}
@@ -2,8 +2,15 @@ package finance
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"time"
 
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/chart"
+	"github.com/piquette/finance-go/datetime"
 	"github.com/shopspring/decimal"
+	"github.com/turbot/steampipe-plugin-finance/pkg/edgar"
 
 	"github.com/turbot/steampipe-plugin-sdk/v4/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v4/plugin"
@@ -25,15 +32,159 @@ func usSecHistoryColumns() []*plugin.Column {
 	}
 }
 
+// quoteHistoryKeyColumns :: a required symbol, and an optional timestamp range
+// that bounds the chart request
+func quoteHistoryKeyColumns() plugin.KeyColumnSlice {
+	return plugin.KeyColumnSlice{
+		{Name: "symbol", Require: plugin.Required},
+		{Name: "timestamp", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+	}
+}
+
+// chartTimeRange :: the start and end of the chart request from the timestamp
+// quals. The end defaults to now, the start is zero without a lower bound.
+func chartTimeRange(d *plugin.QueryData, now time.Time) (time.Time, time.Time) {
+	var start, end time.Time
+	if d.Quals["timestamp"] != nil {
+		for _, q := range d.Quals["timestamp"].Quals {
+			t := q.Value.GetTimestampValue().AsTime()
+			switch q.Operator {
+			case "=":
+				start, end = t, t
+			case ">", ">=":
+				if start.IsZero() || t.After(start) {
+					start = t
+				}
+			case "<", "<=":
+				if end.IsZero() || t.Before(end) {
+					end = t
+				}
+			}
+		}
+	}
+	if end.IsZero() || end.After(now) {
+		end = now
+	}
+	return start, end
+}
+
+// streamChart :: stream the bars of a symbol between start and end, maxSpan
+// at a time if set, until the query has all the rows it needs
+func streamChart(ctx context.Context, d *plugin.QueryData, symbol string, interval datetime.Interval, start, end time.Time, maxSpan time.Duration) error {
+	return chartBars(symbol, interval, start, end, maxSpan, func(bar *finance.ChartBar) bool {
+		d.StreamListItem(ctx, bar)
+		return d.QueryStatus.RowsRemaining(ctx) != 0
+	})
+}
+
+// chartBars :: pass the bars of a symbol between start and end to emit, until
+// it returns false. Ranges longer than maxSpan, if set, are fetched in chunks.
+// Both ends of a chart range are inclusive, so a bar on the boundary of two
+// chunks is only emitted once. An empty range, from an = qual, is widened by a
+// second as Yahoo needs start < end.
+func chartBars(symbol string, interval datetime.Interval, start, end time.Time, maxSpan time.Duration, emit func(*finance.ChartBar) bool) error {
+	if start.After(end) {
+		return nil
+	}
+	if start.Equal(end) {
+		end = end.Add(time.Second)
+	}
+	last := 0
+	for chunkStart := start; ; {
+		chunkEnd := end
+		if maxSpan > 0 && chunkEnd.Sub(chunkStart) > maxSpan {
+			chunkEnd = chunkStart.Add(maxSpan)
+		}
+		params := &chart.Params{
+			Symbol:   symbol,
+			Start:    datetime.New(&chunkStart),
+			End:      datetime.New(&chunkEnd),
+			Interval: interval,
+		}
+		iter := chart.Get(params)
+		for iter.Next() {
+			bar := iter.Bar()
+			if bar.Timestamp <= last {
+				continue
+			}
+			last = bar.Timestamp
+			if !emit(bar) {
+				return nil
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return err
+		}
+		if !chunkEnd.Before(end) {
+			return nil
+		}
+		chunkStart = chunkEnd
+	}
+}
+
+// connect :: return the EDGAR client for this connection, building it from the
+// connection config on first use and caching it so that every hydrate call
+// shares one HTTP connection pool
+func connect(ctx context.Context, d *plugin.QueryData) (edgar.Client, error) {
+	cacheKey := "edgar"
+	if cachedData, ok := d.ConnectionCache.Get(ctx, cacheKey); ok {
+		return cachedData.(edgar.Client), nil
+	}
+
+	config := GetConfig(d.Connection).edgarConfig()
+	if config.IEXToken == "" && config.UserAgent == "" {
+		return nil, errors.New("neither iex_api_key nor sec_user_agent is configured, set them in finance.spc or with the IEX_API_KEY and SEC_USER_AGENT environment variables")
+	}
+	client := edgar.NewClient(config)
+
+	if err := d.ConnectionCache.Set(ctx, cacheKey, client); err != nil {
+		plugin.Logger(ctx).Warn("connect", "cache_error", err)
+	}
+	return client, nil
+}
+
+// isNotFoundError :: whether the error is a 404 from EDGAR, e.g. a concept the
+// company has never reported, which is returned as an empty result
+func isNotFoundError(_ context.Context, _ *plugin.QueryData, _ *plugin.HydrateData, err error) bool {
+	var apiErr *edgar.APIError
+	return errors.As(err, &apiErr) && apiErr.Response.StatusCode == http.StatusNotFound
+}
+
 func symbolString(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
 	quals := d.KeyColumnQuals
 	s := quals["symbol"].GetStringValue()
 	return s, nil
 }
 
-
 func decimalToDouble(ctx context.Context, d *transform.TransformData) (interface{}, error) {
 	dec := d.Value.(decimal.Decimal)
 	f, _ := dec.Float64()
 	return f, nil
 }
+
+// transformIntToBool :: convert the 0/1 integer flags used by EDGAR to booleans
+func transformIntToBool(_ context.Context, td *transform.TransformData) (interface{}, error) {
+	switch v := td.Value.(type) {
+	case *int8:
+		if v == nil {
+			return nil, nil
+		}
+		return *v != 0, nil
+	case int8:
+		return v != 0, nil
+	}
+	return nil, nil
+}
+
+// transformDate :: parse a YYYY-MM-DD date string, null if empty
+func transformDate(_ context.Context, td *transform.TransformData) (interface{}, error) {
+	value, ok := td.Value.(string)
+	if !ok {
+		return nil, nil
+	}
+	date := edgar.ParseDate(value)
+	if date == nil {
+		return nil, nil
+	}
+	return *date, nil
+}
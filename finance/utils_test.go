@@ -0,0 +1,102 @@
+package finance
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	finance "github.com/piquette/finance-go"
+	"github.com/piquette/finance-go/datetime"
+	"github.com/piquette/finance-go/form"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChartBackend serves a bar every step seconds of each requested range,
+// including both ends, as the Yahoo chart API does
+type fakeChartBackend struct {
+	step   int
+	ranges [][2]int
+}
+
+func (b *fakeChartBackend) Call(_ string, body *form.Values, _ *context.Context, v interface{}) error {
+	start, _ := strconv.Atoi(body.Get("period1")[0])
+	end, _ := strconv.Atoi(body.Get("period2")[0])
+	b.ranges = append(b.ranges, [2]int{start, end})
+
+	timestamps, prices, volumes := []int{}, []float64{}, []int{}
+	for t := (start + b.step - 1) / b.step * b.step; t <= end; t += b.step {
+		timestamps = append(timestamps, t)
+		prices = append(prices, float64(t))
+		volumes = append(volumes, 1)
+	}
+	quote := map[string]interface{}{"open": prices, "low": prices, "high": prices, "close": prices, "volume": volumes}
+	resp := map[string]interface{}{"chart": map[string]interface{}{"result": []interface{}{
+		map[string]interface{}{"timestamp": timestamps, "indicators": map[string]interface{}{"quote": []interface{}{quote}}},
+	}}}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// useFakeBackend swaps the Yahoo backend for the length of a test
+func useFakeBackend(t *testing.T, b finance.Backend) {
+	saved := finance.GetBackend(finance.YFinBackend)
+	finance.SetBackend(finance.YFinBackend, b)
+	t.Cleanup(func() { finance.SetBackend(finance.YFinBackend, saved) })
+}
+
+// TestChartBarsChunks fetches a range longer than maxSpan in chunks, without
+// repeating the bars on chunk boundaries.
+func TestChartBarsChunks(t *testing.T) {
+	backend := &fakeChartBackend{step: 60}
+	useFakeBackend(t, backend)
+
+	start := time.Unix(1_700_000_000-1_700_000_000%86400, 0)
+	end := start.Add(20 * day)
+	timestamps := []int{}
+	err := chartBars("AAPL", datetime.OneMin, start, end, 7*day, func(bar *finance.ChartBar) bool {
+		timestamps = append(timestamps, bar.Timestamp)
+		return true
+	})
+	require.NoError(t, err)
+
+	require.Len(t, backend.ranges, 3)
+	require.Equal(t, int(start.Unix()), backend.ranges[0][0])
+	require.Equal(t, backend.ranges[0][1], backend.ranges[1][0])
+	require.Equal(t, int(end.Unix()), backend.ranges[2][1])
+
+	require.Len(t, timestamps, 20*24*60+1)
+	for i := 1; i < len(timestamps); i++ {
+		require.Equal(t, timestamps[i-1]+60, timestamps[i])
+	}
+}
+
+// TestChartBarsStop stops fetching chunks once emit has all it needs.
+func TestChartBarsStop(t *testing.T) {
+	backend := &fakeChartBackend{step: 60}
+	useFakeBackend(t, backend)
+
+	start := time.Unix(1_700_000_000, 0)
+	count := 0
+	err := chartBars("AAPL", datetime.OneMin, start, start.Add(20*day), 7*day, func(bar *finance.ChartBar) bool {
+		count++
+		return count < 10
+	})
+	require.NoError(t, err)
+	require.Equal(t, 10, count)
+	require.Len(t, backend.ranges, 1)
+}
+
+// TestChartBarsEqual widens an empty range, from an = qual, by a second.
+func TestChartBarsEqual(t *testing.T) {
+	backend := &fakeChartBackend{step: 60}
+	useFakeBackend(t, backend)
+
+	at := time.Unix(1_700_000_040, 0)
+	require.NoError(t, chartBars("AAPL", datetime.OneMin, at, at, 0, func(*finance.ChartBar) bool { return true }))
+	require.Equal(t, [][2]int{{1_700_000_040, 1_700_000_041}}, backend.ranges)
+}
@@ -5,19 +5,49 @@ package edgar
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 )
 
-// TODO: determine how to break dependency on IEX as it costs $49.99/month
-//  1. Can just use IEX to get CIK by name and pay the cost
-//  2. Can use EDGAR `curl -d "company=Google" -X POST https://www.sec.gov/cgi-bin/cik_lookup`
-//     a. Need to implement some sort of keystroke search and fuzzy string search for when customers are searching for a company
+// NOTE: IEX reference data costs $49.99/month, GetSECCompanies is a free alternative
+// built on SEC's company tickers list, with fewer fields.
 const (
-	iexSymbolsURL = "https://cloud.iexapis.com/stable/ref-data/symbols"
-	secCompanyURL = "https://data.sec.gov/submissions/"
+	DefaultIEXBaseURL         = "https://cloud.iexapis.com/stable"
+	DefaultSECDataBaseURL     = "https://data.sec.gov"
+	DefaultSECArchivesBaseURL = "https://www.sec.gov/Archives"
+	DefaultSECFilesBaseURL    = "https://www.sec.gov/files"
+	DefaultSECEFTSBaseURL     = "https://efts.sec.gov/LATEST"
+
+	iexSymbolsPath     = "/ref-data/symbols"
+	secSubmissionsPath = "/submissions/"
+	secEdgarDataPath   = "/edgar/data"
+	secFullIndexPath   = "/edgar/full-index"
+	secDailyIndexPath  = "/edgar/daily-index"
+	secSearchIndexPath = "/search-index"
+
+	secCompanyFactsPath   = "/api/xbrl/companyfacts/"
+	secCompanyConceptPath = "/api/xbrl/companyconcept/"
+	secFramesPath         = "/api/xbrl/frames/"
+
+	filingIndexJSON = "index.json"
+
+	secTickersPath         = "/company_tickers.json"
+	secTickersExchangePath = "/company_tickers_exchange.json"
+
+	defaultTimeout = 30 * time.Second
+)
+
+var (
+	// ErrMissingIEXToken is returned when an IEX endpoint is called without an API token.
+	ErrMissingIEXToken = errors.New("edgar: no IEX API token configured")
+	// ErrMissingUserAgent is returned when an SEC endpoint is called without a declared user agent.
+	ErrMissingUserAgent = errors.New("edgar: no SEC user agent configured, SEC requires a name and email address")
 )
 
 // Client definition
@@ -25,38 +55,165 @@ const (
 
 type Client interface {
 	GetPublicCompanies() (*[]Company, error)
+	GetSECCompanies() (*[]Company, error)
 	GetSubmissions(cik string) (*SubmissionsSearchResult, error) // TODO: add time window function
+	GetSubmissionsFile(name string) (*FilingTable, error)
+	GetCompanyFacts(cik string) (*CompanyFacts, error)
+	GetCompanyConcept(cik, taxonomy, tag string) (*CompanyConcept, error)
+	GetFrame(taxonomy, tag, unit, period string) (*Frame, error)
+	GetFilingDocuments(cik, accessionNumber string) (*[]FilingDocument, error)
+	GetDocument(url string, maxSize int64) (*Document, error)
+	GetOwnershipDocument(cik, accessionNumber, primaryDocument string) (*OwnershipDocument, error)
+	GetInformationTable(cik, accessionNumber string) (*InformationTable, error)
+	GetFullIndex(year, quarter int) (*[]IndexEntry, error)
+	GetDailyIndex(date time.Time) (*[]IndexEntry, error)
+	SearchFullText(query FullTextQuery) (*FullTextSearchResult, error)
+	FilingIndexURL(cik, accessionNumber string) (string, error)
+	FilingDocumentURL(cik, accessionNumber, document string) (string, error)
+}
+
+// Config holds the credentials and endpoints used by a client. Empty base
+// URLs fall back to the public IEX and SEC endpoints.
+type Config struct {
+	IEXToken           string
+	UserAgent          string
+	IEXBaseURL         string
+	SECDataBaseURL     string
+	SECArchivesBaseURL string
+	SECFilesBaseURL    string
+	SECEFTSBaseURL     string
+
+	// Timeout bounds each request, including reading the response body.
+	// Defaults to 30 seconds.
+	Timeout time.Duration
+	// SECRequestsPerSecond lowers the process-wide rate of requests to SEC
+	// endpoints. Values above DefaultSECRequestsPerSecond have no effect.
+	SECRequestsPerSecond int
+	// MaxRetries is the number of times a failed GET is retried, defaults to
+	// DefaultMaxRetries. Set it to 0 to disable retries.
+	MaxRetries *int
+	// MinRetryDelay and MaxRetryDelay bound the backoff between retries,
+	// defaulting to 500 milliseconds and 30 seconds.
+	MinRetryDelay time.Duration
+	MaxRetryDelay time.Duration
+	// HTTPClient replaces the pooled client built by NewClient, e.g. in tests.
+	HTTPClient *http.Client
 }
 
 type client struct {
-	iexToken string
-	headers  map[string]string
+	iexToken           string
+	userAgent          string
+	iexBaseURL         string
+	secDataBaseURL     string
+	secArchivesBaseURL string
+	secFilesBaseURL    string
+	secEFTSBaseURL     string
+	httpClient         *http.Client
+	retry              retryPolicy
 }
 
-// NewClient returns a pointer to a new EDGR Piquette client
-func NewClient(token string) *client {
+// NewClient returns a pointer to a new EDGR Piquette client. A client is safe
+// for concurrent use and should be shared, so that its connection pool is
+// reused across requests.
+func NewClient(config Config) *client {
 	c := client{}
-	c.iexToken = token
+	c.httpClient = config.HTTPClient
+	if c.httpClient == nil {
+		c.httpClient = newHTTPClient(config.Timeout)
+	}
+	c.retry = newRetryPolicy(config)
+	secLimiter.Limit(config.SECRequestsPerSecond)
+	c.iexToken = config.IEXToken
+	c.userAgent = config.UserAgent
+	c.iexBaseURL = withDefault(config.IEXBaseURL, DefaultIEXBaseURL)
+	c.secDataBaseURL = withDefault(config.SECDataBaseURL, DefaultSECDataBaseURL)
+	c.secArchivesBaseURL = withDefault(config.SECArchivesBaseURL, DefaultSECArchivesBaseURL)
+	c.secFilesBaseURL = withDefault(config.SECFilesBaseURL, DefaultSECFilesBaseURL)
+	c.secEFTSBaseURL = withDefault(config.SECEFTSBaseURL, DefaultSECEFTSBaseURL)
 	return &c
 }
 
+// newHTTPClient returns an http.Client that keeps enough idle connections per
+// host for a full set of concurrent hydrate calls against data.sec.gov.
+func newHTTPClient(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 20
+	transport.IdleConnTimeout = 90 * time.Second
+	transport.TLSHandshakeTimeout = 10 * time.Second
+	transport.ResponseHeaderTimeout = timeout
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+}
+
+func withDefault(url, fallback string) string {
+	if url == "" {
+		return fallback
+	}
+	return strings.TrimRight(url, "/")
+}
+
 func (c *client) request(method, url string, body interface{}) (*http.Response, error) {
 	payload, err := marshall(body)
 	if err != nil {
 		return nil, err
 	}
+	if payload == nil {
+		return c.do(method, url, nil)
+	}
 
 	return c.do(method, url, bytes.NewReader(payload))
 }
 
+// do sends the request, retrying idempotent requests that fail with a
+// transport error or a transient status code.
 func (c *client) do(method, url string, body io.Reader) (*http.Response, error) {
+	attempts := c.retry.attempts(method)
+	for attempt := 1; ; attempt++ {
+		res, err := c.send(method, url, body)
+		if errors.Is(err, ErrMissingIEXToken) || errors.Is(err, ErrMissingUserAgent) {
+			return nil, err
+		}
+		if attempt == attempts || !retryable(res, err) {
+			if attempt > 1 && err == nil {
+				log.Printf("[INFO] edgar: %s %s returned %d on attempt %d of %d", method, redact(url), res.StatusCode, attempt, attempts)
+			}
+			return res, err
+		}
+
+		delay, ok := c.retry.delay(attempt, res)
+		if !ok {
+			return res, err
+		}
+		reason := fmt.Sprint(err)
+		if res != nil {
+			reason = res.Status
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		log.Printf("[WARN] edgar: %s %s failed on attempt %d of %d (%s), retrying in %s", method, redact(url), attempt, attempts, reason, delay)
+		time.Sleep(delay)
+	}
+}
+
+// send makes a single attempt at the request.
+func (c *client) send(method, url string, body io.Reader) (*http.Response, error) {
 	req, err := http.NewRequest(method, url, body)
 	if err != nil {
 		return nil, err
 	}
 
 	// if we are using the IEX API, add the token to the request parameters
-	if strings.Contains(url, "cloud.iexapis.com") {
+	isIEX := strings.HasPrefix(url, c.iexBaseURL)
+	if isIEX {
+		if c.iexToken == "" {
+			return nil, ErrMissingIEXToken
+		}
 		// appending to existing query args
 		q := req.URL.Query()
 		q.Add("token", c.iexToken)
@@ -65,20 +222,67 @@ func (c *client) do(method, url string, body io.Reader) (*http.Response, error)
 		req.URL.RawQuery = q.Encode()
 	}
 
-	c.headers = make(map[string]string)
+	headers := make(map[string]string)
 	if body != nil {
-		c.headers["Content-Type"] = "application/json"
+		headers["Content-Type"] = "application/json"
+	}
+	// NOTE: SEC fair access rules require a declared "Name email@domain.com" user agent,
+	// see https://www.sec.gov/os/webmaster-faq#developers
+	if !isIEX {
+		if c.userAgent == "" {
+			return nil, ErrMissingUserAgent
+		}
+		headers["User-Agent"] = c.userAgent
 	}
-	// NOTE: see https://stackoverflow.com/questions/68131406/downloading-files-from-sec-gov-via-edgar-using-python-3-9
-	// TODO: replace with headers on https://www.sec.gov/os/webmaster-faq#developers
-	c.headers["User-Agent"] = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Safari/537.36"
-	c.headers["Accept"] = "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8,application/signed-exchange;v=b3;q=0.9"
+	headers["Accept"] = "application/json, text/html;q=0.9, */*;q=0.8"
 
-	for key, value := range c.headers {
+	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
 
-	return http.DefaultClient.Do(req)
+	if !isIEX {
+		secLimiter.Wait()
+	}
+	return c.httpClient.Do(req)
+}
+
+// redact strips the query string, which carries the IEX token, from a URL
+// before it is logged.
+func redact(url string) string {
+	if i := strings.Index(url, "?"); i >= 0 {
+		return url[:i]
+	}
+	return url
+}
+
+// FilingIndexURL returns the URL of the index page of a filing, e.g.
+// https://www.sec.gov/Archives/edgar/data/320193/000121465923000970/0001214659-23-000970-index.htm
+func (c *client) FilingIndexURL(cik, accessionNumber string) (string, error) {
+	folder, err := c.filingFolderURL(cik, accessionNumber)
+	if err != nil {
+		return "", err
+	}
+	return folder + "/" + accessionNumber + "-index.htm", nil
+}
+
+// FilingDocumentURL returns the URL of a single document within a filing.
+func (c *client) FilingDocumentURL(cik, accessionNumber, document string) (string, error) {
+	folder, err := c.filingFolderURL(cik, accessionNumber)
+	if err != nil {
+		return "", err
+	}
+	return folder + "/" + document, nil
+}
+
+// filingFolderURL returns the archive folder of a filing, which uses the CIK
+// without leading zeros and the accession number without dashes.
+func (c *client) filingFolderURL(cik, accessionNumber string) (string, error) {
+	cikInt, err := strconv.ParseInt(cik, 10, 64)
+	if err != nil {
+		return "", err
+	}
+	compactAccessionNumber := strings.Replace(accessionNumber, "-", "", -1)
+	return strings.Join([]string{c.secArchivesBaseURL + secEdgarDataPath, fmt.Sprint(cikInt), compactAccessionNumber}, "/"), nil
 }
 
 func marshall(in interface{}) ([]byte, error) {
@@ -90,7 +294,34 @@ func marshall(in interface{}) ([]byte, error) {
 }
 
 func unmarshall(res *http.Response, out interface{}) error {
-	fmt.Println(res)
+	defer res.Body.Close()
+	if err := checkResponse(res); err != nil {
+		return err
+	}
+
+	if out != nil {
+		return json.NewDecoder(res.Body).Decode(out)
+	}
+
+	return nil
+}
+
+// readAll returns the body of a non-JSON response, e.g. an HTML page, reading
+// at most limit bytes if limit is positive.
+func readAll(res *http.Response, limit int64) ([]byte, error) {
+	defer res.Body.Close()
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+
+	if limit > 0 {
+		return io.ReadAll(io.LimitReader(res.Body, limit))
+	}
+	return io.ReadAll(res.Body)
+}
+
+// checkResponse turns a non-2xx response into an APIError.
+func checkResponse(res *http.Response) error {
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		apiErr := new(APIError)
 		apiErr.Response = res
@@ -99,11 +330,6 @@ func unmarshall(res *http.Response, out interface{}) error {
 		}
 		return apiErr
 	}
-
-	if out != nil {
-		return json.NewDecoder(res.Body).Decode(out)
-	}
-
 	return nil
 }
 
@@ -117,7 +343,7 @@ type APIError struct {
 }
 
 func (e *APIError) Error() string {
-	msg := fmt.Sprintf("%v %v: %d", e.Response.Request.Method, e.Response.Request.URL, e.Response.StatusCode)
+	msg := fmt.Sprintf("%v %v: %d", e.Response.Request.Method, redact(e.Response.Request.URL.String()), e.Response.StatusCode)
 
 	if e.Value != nil {
 		msg = fmt.Sprintf("%s %v", msg, *e.Value)
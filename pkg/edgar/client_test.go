@@ -1,9 +1,13 @@
 package edgar
 
 import (
+	"compress/gzip"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -16,7 +20,7 @@ func TestGetPublicCompanies(t *testing.T) {
 	if apiKey == "" {
 		t.Fatalf("No IEX API Key found")
 	}
-	client := NewClient(apiKey)
+	client := NewClient(Config{IEXToken: apiKey})
 	result, err := client.GetPublicCompanies()
 	require.NoError(t, err)
 	for i, org := range *result {
@@ -31,12 +35,11 @@ func TestGetPublicCompanies(t *testing.T) {
 // TestGetSubmissions calls GetSubmissions with a basic *SubmissionsSearchResult
 // for a valid return value.
 func TestGetSubmissions(t *testing.T) {
-	apiKey := os.Getenv("IEX_API_KEY")
-	fmt.Println("IEX API key = ", apiKey)
-	if apiKey == "" {
-		t.Fatalf("No IEX API Key found")
+	userAgent := os.Getenv("SEC_USER_AGENT")
+	if userAgent == "" {
+		t.Fatalf("No SEC user agent found")
 	}
-	client := NewClient(apiKey)
+	client := NewClient(Config{UserAgent: userAgent})
 	result, err := client.GetSubmissions("0001650373")
 	require.NoError(t, err)
 	for i, num := range *result.Filings.Recent.IsInlineXBRL {
@@ -48,3 +51,406 @@ func TestGetSubmissions(t *testing.T) {
 	// jsonBytes, err := json.Marshal(result)
 	// fmt.Println(string(jsonBytes))
 }
+
+// TestGetSECCompanies maps company_tickers_exchange.json onto Company.
+func TestGetSECCompanies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/files/company_tickers_exchange.json", r.URL.Path)
+		w.Write([]byte(`{"fields":["cik","name","ticker","exchange"],"data":[[320193,"Apple Inc.","AAPL","Nasdaq"],[1067983,"BERKSHIRE HATHAWAY INC","BRK-B",null]]}`))
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server, 0).GetSECCompanies()
+	require.NoError(t, err)
+	require.Len(t, *result, 2)
+	apple := (*result)[0]
+	require.Equal(t, "AAPL", *apple.Symbol)
+	require.Equal(t, "Apple Inc.", *apple.Name)
+	require.Equal(t, "0000320193", *apple.CIK)
+	require.Equal(t, "Nasdaq", *apple.Exchange)
+	require.Nil(t, (*result)[1].Exchange)
+}
+
+// TestGetSECCompaniesFallback uses company_tickers.json when the exchange
+// list is unavailable.
+func TestGetSECCompaniesFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/files/company_tickers.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"1":{"cik_str":789019,"ticker":"MSFT","title":"MICROSOFT CORP"},"0":{"cik_str":320193,"ticker":"AAPL","title":"Apple Inc."}}`))
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server, 0).GetSECCompanies()
+	require.NoError(t, err)
+	require.Len(t, *result, 2)
+	require.Equal(t, "AAPL", *(*result)[0].Symbol)
+	require.Equal(t, "0000789019", *(*result)[1].CIK)
+}
+
+// TestGetSubmissionsFile follows FilingRecord.Files to an older page of filings.
+func TestGetSubmissionsFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/submissions/CIK0000320193.json":
+			w.Write([]byte(`{"cik":"320193","filings":{"recent":{"accessionNumber":["0000320193-23-000106"]},"files":[{"name":"CIK0000320193-submissions-001.json","filingCount":2,"filingFrom":"1994-01-26","filingTo":"2014-04-24"}]}}`))
+		case "/submissions/CIK0000320193-submissions-001.json":
+			w.Write([]byte(`{"accessionNumber":["0001193125-14-157311","0000320193-94-000016"],"filingDate":["2014-04-24","1994-01-26"]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server, 0)
+	result, err := client.GetSubmissions("0000320193")
+	require.NoError(t, err)
+	require.Len(t, *result.Filings.Files, 1)
+	file := (*result.Filings.Files)[0]
+	require.Equal(t, "2014-04-24", file.FilingTo)
+
+	filings, err := client.GetSubmissionsFile(file.Name)
+	require.NoError(t, err)
+	require.Equal(t, []string{"2014-04-24", "1994-01-26"}, *filings.FilingDate)
+}
+
+// TestGetCompanyFacts decodes the taxonomy, concept and unit nesting of companyfacts.
+func TestGetCompanyFacts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/xbrl/companyfacts/CIK0000320193.json", r.URL.Path)
+		w.Write([]byte(`{"cik":320193,"entityName":"Apple Inc.","facts":{"us-gaap":{"Revenues":{"label":"Revenues","units":{"USD":[{"start":"2022-09-25","end":"2023-09-30","val":383285000000,"accn":"0000320193-23-000106","fy":2023,"fp":"FY","form":"10-K","filed":"2023-11-03","frame":"CY2023"}]}}}}}`))
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server, 0).GetCompanyFacts("320193")
+	require.NoError(t, err)
+	require.Equal(t, "Apple Inc.", *result.EntityName)
+	facts := result.Facts["us-gaap"]["Revenues"].Units["USD"]
+	require.Len(t, facts, 1)
+	require.Equal(t, 383285000000.0, *facts[0].Val)
+	require.Equal(t, 2023, *facts[0].FY)
+	require.Equal(t, "0000320193-23-000106", *facts[0].Accn)
+}
+
+// TestGetCompanyConcept requests a single concept by taxonomy and tag.
+func TestGetCompanyConcept(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/xbrl/companyconcept/CIK0000320193/us-gaap/AccountsPayableCurrent.json", r.URL.Path)
+		w.Write([]byte(`{"cik":320193,"taxonomy":"us-gaap","tag":"AccountsPayableCurrent","label":"Accounts Payable, Current","entityName":"Apple Inc.","units":{"USD":[{"end":"2023-09-30","val":62611000000,"accn":"0000320193-23-000106","fy":2023,"fp":"FY","form":"10-K","filed":"2023-11-03"}]}}`))
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server, 0).GetCompanyConcept("0000320193", "us-gaap", "AccountsPayableCurrent")
+	require.NoError(t, err)
+	require.Equal(t, "Accounts Payable, Current", *result.Label)
+	require.Len(t, result.Units["USD"], 1)
+	require.Nil(t, result.Units["USD"][0].Start)
+}
+
+// TestGetFrame decodes one value per filer for a calendar period.
+func TestGetFrame(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/xbrl/frames/us-gaap/Revenues/USD/CY2023Q4.json", r.URL.Path)
+		w.Write([]byte(`{"taxonomy":"us-gaap","tag":"Revenues","ccp":"CY2023Q4","uom":"USD","label":"Revenues","pts":2,"data":[{"accn":"0001104659-24-011236","cik":1750,"entityName":"AAR CORP.","loc":"US-IL","start":"2023-09-01","end":"2023-11-30","val":545400000},{"accn":"0000002488-24-000012","cik":2488,"entityName":"ADVANCED MICRO DEVICES INC","loc":"US-CA","start":"2023-10-01","end":"2023-12-30","val":6168000000}]}`))
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server, 0).GetFrame("us-gaap", "Revenues", "USD", "CY2023Q4")
+	require.NoError(t, err)
+	require.Len(t, result.Data, 2)
+	require.EqualValues(t, 2488, *result.Data[1].CIK)
+	require.Equal(t, "US-CA", *result.Data[1].Loc)
+}
+
+// TestGetFilingDocuments merges index.json with the filing index page.
+func TestGetFilingDocuments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Archives/edgar/data/320193/000032019323000106/index.json":
+			w.Write([]byte(`{"directory":{"name":"/Archives/edgar/data/320193/000032019323000106","item":[{"last-modified":"2023-11-02 18:08:27","name":"aapl-20230930.htm","type":"text.gif","size":"9507744"},{"last-modified":"2023-11-02 18:08:27","name":"a10-kexhibit2112023.htm","type":"text.gif","size":"4390"},{"last-modified":"2023-11-02 18:08:27","name":"R1.htm","type":"text.gif","size":""}]}}`))
+		case "/Archives/edgar/data/320193/000032019323000106/0000320193-23-000106-index.htm":
+			w.Write([]byte(`<html><body><table class="tableFile" summary="Document Format Files">
+<tr><th scope="col">Seq</th><th scope="col">Description</th><th scope="col">Document</th><th scope="col">Type</th><th scope="col">Size</th></tr>
+<tr><td scope="row">1</td><td scope="row">10-K</td><td scope="row"><a href="/ix?doc=/Archives/edgar/data/320193/000032019323000106/aapl-20230930.htm">aapl-20230930.htm</a> &nbsp;&nbsp;<span>iXBRL</span></td><td scope="row">10-K</td><td scope="row">9507744</td></tr>
+<tr class="evenRow"><td scope="row">3</td><td scope="row">EX-21.1</td><td scope="row"><a href="/Archives/edgar/data/320193/000032019323000106/a10-kexhibit2112023.htm">a10-kexhibit2112023.htm</a></td><td scope="row">EX-21.1</td><td scope="row">4390</td></tr>
+</table></body></html>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server, 0).GetFilingDocuments("0000320193", "0000320193-23-000106")
+	require.NoError(t, err)
+	require.Len(t, *result, 3)
+	primary := (*result)[0]
+	require.Equal(t, 1, *primary.Sequence)
+	require.Equal(t, "10-K", *primary.Type)
+	require.EqualValues(t, 9507744, *primary.Size)
+	require.Equal(t, server.URL+"/Archives/edgar/data/320193/000032019323000106/aapl-20230930.htm", primary.URL)
+	require.Equal(t, "EX-21.1", *(*result)[1].Type)
+	require.Nil(t, (*result)[2].Sequence)
+	require.Nil(t, (*result)[2].Size)
+}
+
+func TestGetDocument(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><head><title>10-K</title></head><body><div style="display:none"><ix:header><ix:hidden>dei:AmendmentFlag</ix:hidden></ix:header></div>
+<p>Item 1A.&nbsp;Risk <b>Factors</b></p><table><tr><td>Net sales</td><td><ix:nonFraction name="us-gaap:Revenues">383,285</ix:nonFraction></td></tr></table><script>var x;</script></body></html>`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server, 0)
+	doc, err := client.GetDocument(server.URL+"/Archives/edgar/data/320193/000032019323000106/aapl-20230930.htm", 0)
+	require.NoError(t, err)
+	require.False(t, doc.Truncated)
+	require.Equal(t, "Item 1A. Risk Factors\n\nNet sales 383,285", doc.Text())
+
+	doc, err = client.GetDocument(server.URL+"/Archives/edgar/data/320193/000032019323000106/aapl-20230930.htm", 10)
+	require.NoError(t, err)
+	require.True(t, doc.Truncated)
+	require.Len(t, doc.Body, 10)
+
+	_, err = client.GetDocument("https://example.com/Archives/doc.htm", 0)
+	require.ErrorIs(t, err, ErrNotArchivesURL)
+}
+
+func TestGetOwnershipDocument(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/Archives/edgar/data/320193/000032019323000093/wk-form4_1696890609.xml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`<?xml version="1.0"?>
+<ownershipDocument>
+	<documentType>4</documentType>
+	<periodOfReport>2023-10-02</periodOfReport>
+	<issuer><issuerCik>0000320193</issuerCik><issuerName>Apple Inc.</issuerName><issuerTradingSymbol>AAPL</issuerTradingSymbol></issuer>
+	<reportingOwner>
+		<reportingOwnerId><rptOwnerCik>0001214156</rptOwnerCik><rptOwnerName>COOK TIMOTHY D</rptOwnerName></reportingOwnerId>
+		<reportingOwnerRelationship><isDirector>1</isDirector><isOfficer>true</isOfficer><officerTitle>Chief Executive Officer</officerTitle></reportingOwnerRelationship>
+	</reportingOwner>
+	<nonDerivativeTable>
+		<nonDerivativeTransaction>
+			<securityTitle><value>Common Stock</value></securityTitle>
+			<transactionDate><value>2023-10-02-05:00</value></transactionDate>
+			<transactionCoding><transactionFormType>4</transactionFormType><transactionCode>S</transactionCode><equitySwapInvolved>0</equitySwapInvolved></transactionCoding>
+			<transactionAmounts>
+				<transactionShares><value>196410</value></transactionShares>
+				<transactionPricePerShare><value>173.8</value><footnoteId id="F2"/></transactionPricePerShare>
+				<transactionAcquiredDisposedCode><value>D</value></transactionAcquiredDisposedCode>
+			</transactionAmounts>
+			<postTransactionAmounts><sharesOwnedFollowingTransaction><value>3280180</value></sharesOwnedFollowingTransaction></postTransactionAmounts>
+			<ownershipNature><directOrIndirectOwnership><value>D</value></directOrIndirectOwnership></ownershipNature>
+		</nonDerivativeTransaction>
+	</nonDerivativeTable>
+	<derivativeTable>
+		<derivativeTransaction>
+			<securityTitle><value>Restricted Stock Unit</value></securityTitle>
+			<transactionCoding><transactionCode>M</transactionCode></transactionCoding>
+			<transactionAmounts><transactionShares><value>511000</value></transactionShares><transactionPricePerShare><footnoteId id="F1"/></transactionPricePerShare></transactionAmounts>
+			<underlyingSecurity><underlyingSecurityTitle><value>Common Stock</value></underlyingSecurityTitle><underlyingSecurityShares><value>511000</value></underlyingSecurityShares></underlyingSecurity>
+		</derivativeTransaction>
+	</derivativeTable>
+</ownershipDocument>`))
+	}))
+	defer server.Close()
+
+	doc, err := newTestClient(server, 0).GetOwnershipDocument("0000320193", "0000320193-23-000093", "xslF345X05/wk-form4_1696890609.xml")
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/Archives/edgar/data/320193/000032019323000093/wk-form4_1696890609.xml", doc.URL)
+	require.Equal(t, "AAPL", doc.IssuerTradingSymbol)
+	require.Len(t, doc.ReportingOwners, 1)
+	owner := doc.ReportingOwners[0]
+	require.True(t, bool(owner.IsDirector))
+	require.True(t, bool(owner.IsOfficer))
+	require.False(t, bool(owner.IsTenPercentOwner))
+
+	require.Len(t, doc.NonDerivativeTransactions, 1)
+	sale := doc.NonDerivativeTransactions[0]
+	require.Equal(t, "S", sale.TransactionCode)
+	require.Equal(t, 196410.0, *sale.Shares)
+	require.Equal(t, 173.8, *sale.PricePerShare)
+	require.Equal(t, 3280180.0, *sale.SharesOwnedFollowingTransaction)
+	require.Equal(t, "2023-10-02", ParseDate(sale.TransactionDate).Format(DateLayout))
+
+	require.Len(t, doc.DerivativeTransactions, 1)
+	require.Nil(t, doc.DerivativeTransactions[0].PricePerShare)
+	require.Equal(t, 511000.0, *doc.DerivativeTransactions[0].UnderlyingSecurityShares)
+}
+
+func TestGetOwnershipDocumentErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Archives/edgar/data/320193/000032019323000093/form4.xml":
+			w.Write([]byte(`<?xml version="1.0"?><ownershipDocument><issuer>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	c := newTestClient(server, 0)
+
+	_, err := c.GetOwnershipDocument("0000320193", "0000320193-23-000093", "")
+	require.ErrorIs(t, err, ErrNoOwnershipDocument)
+
+	_, err = c.GetOwnershipDocument("0000320193", "0000320193-23-000093", "xslF345X05/form4.xml")
+	require.ErrorIs(t, err, ErrInvalidOwnershipDocument)
+
+	_, err = c.GetOwnershipDocument("0000320193", "0000320193-23-000093", "missing.xml")
+	require.True(t, isNotFound(err))
+}
+
+func TestGetInformationTable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Archives/edgar/data/1067983/000095012323011029/index.json":
+			w.Write([]byte(`{"directory":{"name":"/Archives/edgar/data/1067983/000095012323011029","item":[{"last-modified":"2023-11-14 16:02:33","name":"primary_doc.xml","type":"text.gif","size":"2044"},{"last-modified":"2023-11-14 16:02:33","name":"46994.xml","type":"text.gif","size":"23441"}]}}`))
+		case "/Archives/edgar/data/1067983/000095012323011029/0000950123-23-011029-index.htm":
+			w.Write([]byte(`<html><body><table class="tableFile" summary="Document Format Files">
+<tr><td>1</td><td></td><td><a href="/Archives/edgar/data/1067983/000095012323011029/xslForm13F_X02/primary_doc.xml">primary_doc.html</a></td><td>13F-HR</td><td></td></tr>
+<tr><td>2</td><td>INFORMATION TABLE</td><td><a href="/Archives/edgar/data/1067983/000095012323011029/xslForm13F_X02/46994.xml">46994.html</a></td><td>INFORMATION TABLE</td><td></td></tr>
+<tr><td>2</td><td>INFORMATION TABLE</td><td><a href="/Archives/edgar/data/1067983/000095012323011029/46994.xml">46994.xml</a></td><td>INFORMATION TABLE</td><td>23441</td></tr>
+</table></body></html>`))
+		case "/Archives/edgar/data/1067983/000095012323011029/46994.xml":
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<informationTable xmlns="http://www.sec.gov/edgar/document/thirteenf/informationtable">
+	<infoTable>
+		<nameOfIssuer>APPLE INC</nameOfIssuer>
+		<titleOfClass>COM</titleOfClass>
+		<cusip>037833100</cusip>
+		<value>156753093583</value>
+		<shrsOrPrnAmt><sshPrnamt>915560382</sshPrnamt><sshPrnamtType>SH</sshPrnamtType></shrsOrPrnAmt>
+		<investmentDiscretion>DFND</investmentDiscretion>
+		<otherManager>4,8,11</otherManager>
+		<votingAuthority><Sole>915560382</Sole><Shared>0</Shared><None>0</None></votingAuthority>
+	</infoTable>
+</informationTable>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server, 0).GetInformationTable("1067983", "0000950123-23-011029")
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/Archives/edgar/data/1067983/000095012323011029/46994.xml", result.URL)
+	require.Len(t, result.Holdings, 1)
+	holding := result.Holdings[0]
+	require.Equal(t, "037833100", holding.CUSIP)
+	require.Equal(t, 156753093583.0, *holding.Value)
+	require.Equal(t, "SH", holding.SharesOrPrincipalType)
+	require.EqualValues(t, 915560382, *holding.VotingAuthoritySole)
+	require.Empty(t, holding.PutCall)
+}
+
+// TestGetInformationTableTextOnly fails with ErrNoInformationTable for a
+// 13F-HR filed before information tables were XML.
+func TestGetInformationTableTextOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Archives/edgar/data/1067983/000095012312006277/index.json":
+			w.Write([]byte(`{"directory":{"name":"/Archives/edgar/data/1067983/000095012312006277","item":[{"last-modified":"2012-05-15 16:02:33","name":"0000950123-12-006277.txt","type":"text.gif","size":"40211"},{"last-modified":"2012-05-15 16:02:33","name":"c32140e13fvhr.txt","type":"text.gif","size":"38102"}]}}`))
+		case "/Archives/edgar/data/1067983/000095012312006277/0000950123-12-006277-index.htm":
+			w.Write([]byte(`<html><body><table class="tableFile" summary="Document Format Files">
+<tr><td>1</td><td>FORM 13F-HR</td><td><a href="/Archives/edgar/data/1067983/000095012312006277/c32140e13fvhr.txt">c32140e13fvhr.txt</a></td><td>13F-HR</td><td>38102</td></tr>
+</table></body></html>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server, 0).GetInformationTable("1067983", "0000950123-12-006277")
+	require.ErrorIs(t, err, ErrNoInformationTable)
+}
+
+const testMasterIndex = `Description:           Master Index of EDGAR Dissemination Feed
+Last Data Received:    December 31, 2023
+Comments:              webmaster@sec.gov
+Anonymous FTP:         ftp://ftp.sec.gov/edgar/
+
+CIK|Company Name|Form Type|Date Filed|Filename
+--------------------------------------------------------------------------------
+320193|Apple Inc.|10-K|2023-11-03|edgar/data/320193/0000320193-23-000106.txt
+1067983|BERKSHIRE HATHAWAY INC|13F-HR|20231114|edgar/data/1067983/0000950123-23-011029.txt
+`
+
+func TestGetFullIndex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Archives/edgar/full-index/2023/QTR4/master.gz":
+			zw := gzip.NewWriter(w)
+			zw.Write([]byte(testMasterIndex))
+			zw.Close()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server, 0).GetFullIndex(2023, 4)
+	require.NoError(t, err)
+	require.Len(t, *result, 2)
+	apple := (*result)[0]
+	require.Equal(t, "320193", apple.CIK)
+	require.Equal(t, "10-K", apple.FormType)
+	require.Equal(t, "0000320193-23-000106", apple.AccessionNumber)
+	require.Equal(t, server.URL+"/Archives/edgar/data/320193/0000320193-23-000106.txt", apple.URL)
+	require.Equal(t, "2023-11-14", (*result)[1].DateFiled.Format(DateLayout))
+}
+
+func TestGetDailyIndex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Archives/edgar/daily-index/2023/QTR4/master.20231103.idx":
+			w.Write([]byte(testMasterIndex))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server, 0)
+	result, err := client.GetDailyIndex(time.Date(2023, 11, 3, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, *result, 2)
+
+	// weekends have no index
+	result, err = client.GetDailyIndex(time.Date(2023, 11, 4, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Empty(t, *result)
+}
+
+func TestSearchFullText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/efts/search-index" || q.Get("q") != `"going concern"` || q.Get("forms") != "10-K,10-Q" || q.Get("ciks") != "0001000045" ||
+			q.Get("startdt") != "2023-01-01" || q.Get("enddt") != "2023-03-31" || q.Get("from") != "100" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"hits":{"total":{"value":101,"relation":"eq"},"hits":[{"_id":"0000950170-23-002681:nicholas-20221231.htm","_score":12.5,"_source":{"ciks":["0001000045"],"period_ending":"2022-12-31","display_names":["NICHOLAS FINANCIAL INC  (NICK)  (CIK 0001000045)"],"root_forms":["10-Q"],"file_date":"2023-02-14","form":"10-Q","adsh":"0000950170-23-002681","file_type":"10-Q","file_description":null,"items":[]},"highlight":{"text":["substantial doubt about its ability to continue as a <em>going concern</em>"]}}]}}`))
+	}))
+	defer server.Close()
+
+	start, end := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC)
+	result, err := newTestClient(server, 0).SearchFullText(FullTextQuery{
+		Query:     `"going concern"`,
+		Forms:     []string{"10-K", "10-Q"},
+		CIKs:      []string{"1000045"},
+		StartDate: &start,
+		EndDate:   &end,
+		From:      100,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 101, result.Hits.Total.Value)
+	require.Len(t, result.Hits.Hits, 1)
+	hit := result.Hits.Hits[0]
+	require.Equal(t, "nicholas-20221231.htm", hit.DocumentName())
+	require.Equal(t, "0000950170-23-002681", hit.Source.AccessionNumber)
+	require.Equal(t, []string{"substantial doubt about its ability to continue as a <em>going concern</em>"}, hit.Snippets())
+}
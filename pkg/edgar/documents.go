@@ -0,0 +1,181 @@
+package edgar
+
+import (
+	"bytes"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// FilingDocument is a single file within a filing, e.g. the primary
+// document, an exhibit, an XBRL instance or a graphic. Sequence, Type and
+// Description are only known for documents listed on the filing index page.
+type FilingDocument struct {
+	Sequence     *int
+	Type         *string
+	Description  *string
+	Name         string
+	Size         *int64
+	LastModified *time.Time
+	URL          string
+}
+
+// filingDirectory is the index.json listing of a filing's archive folder.
+type filingDirectory struct {
+	Directory struct {
+		Name  string `json:"name"`
+		Items []struct {
+			Name         string `json:"name"`
+			Type         string `json:"type"`
+			Size         string `json:"size"`
+			LastModified string `json:"last-modified"`
+		} `json:"item"`
+	} `json:"directory"`
+}
+
+// Document methods
+// -----------------
+
+// GetFilingDocuments lists the files of a filing from its index.json, adding
+// the sequence, type and description of each document from the filing index
+// page.
+func (c *client) GetFilingDocuments(cik, accessionNumber string) (*[]FilingDocument, error) {
+	out := new([]FilingDocument)
+
+	folder, err := c.filingFolderURL(cik, accessionNumber)
+	if err != nil {
+		return out, err
+	}
+	resp, err := c.request(http.MethodGet, folder+"/"+filingIndexJSON, nil)
+	if err != nil {
+		return out, err
+	}
+	directory := new(filingDirectory)
+	if err = unmarshall(resp, directory); err != nil {
+		return out, err
+	}
+
+	indexURL, err := c.FilingIndexURL(cik, accessionNumber)
+	if err != nil {
+		return out, err
+	}
+	resp, err = c.request(http.MethodGet, indexURL, nil)
+	if err != nil {
+		return out, err
+	}
+	page, err := readAll(resp, 0)
+	if err != nil {
+		return out, err
+	}
+	listed := parseFilingIndex(page)
+
+	for _, item := range directory.Directory.Items {
+		if item.Type == "dir" {
+			continue
+		}
+		doc := FilingDocument{
+			Name: item.Name,
+			URL:  folder + "/" + item.Name,
+		}
+		if size, err := strconv.ParseInt(item.Size, 10, 64); err == nil {
+			doc.Size = &size
+		}
+		if t, err := time.Parse("2006-01-02 15:04:05", item.LastModified); err == nil {
+			doc.LastModified = &t
+		}
+		if l, ok := listed[item.Name]; ok {
+			doc.Sequence = l.Sequence
+			doc.Type = l.Type
+			doc.Description = l.Description
+		}
+		*out = append(*out, doc)
+	}
+	return out, nil
+}
+
+// parseFilingIndex reads the "Document Format Files" and "Data Files" tables
+// of a filing index page, whose columns are Seq, Description, Document, Type
+// and Size, returning the documents keyed by file name.
+func parseFilingIndex(page []byte) map[string]FilingDocument {
+	documents := map[string]FilingDocument{}
+
+	var inTable, inCell bool
+	var cells []string
+	var cell strings.Builder
+	var href string
+
+	z := html.NewTokenizer(bytes.NewReader(page))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return documents
+		case html.StartTagToken:
+			t := z.Token()
+			switch t.Data {
+			case "table":
+				inTable = attr(t, "class") == "tableFile"
+			case "tr":
+				cells = nil
+				href = ""
+			case "td":
+				inCell = inTable
+				cell.Reset()
+			case "a":
+				if inCell && len(cells) == 2 {
+					href = attr(t, "href")
+				}
+			}
+		case html.TextToken:
+			if inCell {
+				cell.Write(z.Text())
+			}
+		case html.EndTagToken:
+			switch z.Token().Data {
+			case "table":
+				inTable = false
+			case "td":
+				if inCell {
+					cells = append(cells, strings.TrimSpace(cell.String()))
+					inCell = false
+				}
+			case "tr":
+				if !inTable || len(cells) < 4 {
+					continue
+				}
+				// the document cell may carry an "iXBRL" badge after the file name,
+				// and iXBRL links go through the viewer, e.g. /ix?doc=/Archives/...
+				name := path.Base(strings.TrimPrefix(href, "/ix?doc="))
+				if fields := strings.Fields(cells[2]); name == "." || name == "/" {
+					if len(fields) == 0 {
+						continue
+					}
+					name = fields[0]
+				}
+				doc := FilingDocument{Name: name}
+				if seq, err := strconv.Atoi(cells[0]); err == nil {
+					doc.Sequence = &seq
+				}
+				if cells[1] != "" {
+					doc.Description = Ptr(cells[1])
+				}
+				if cells[3] != "" {
+					doc.Type = Ptr(cells[3])
+				}
+				documents[name] = doc
+			}
+		}
+	}
+}
+
+func attr(t html.Token, key string) string {
+	for _, a := range t.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+package edgar
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// FullTextSearchPageSize is the number of hits returned per page.
+	FullTextSearchPageSize = 100
+	// FullTextSearchMaxHits is the deepest hit that can be paged to.
+	FullTextSearchMaxHits = 10000
+
+	// fullTextSearchEarliestDate is the start of the full text archive.
+	fullTextSearchEarliestDate = "2001-01-01"
+)
+
+// FullTextQuery is a search of the EDGAR full text archive. Query follows
+// the syntax of the EDGAR full text search page, e.g. "going concern" in
+// double quotes for an exact phrase.
+type FullTextQuery struct {
+	Query     string
+	Forms     []string
+	CIKs      []string
+	StartDate *time.Time
+	EndDate   *time.Time
+	// From is the offset of the first hit to return.
+	From int
+}
+
+// FullTextSearchResult is a page of full text search hits.
+type FullTextSearchResult struct {
+	Hits struct {
+		Total struct {
+			Value    int    `json:"value"`
+			Relation string `json:"relation"`
+		} `json:"total"`
+		Hits []FullTextSearchHit `json:"hits"`
+	} `json:"hits"`
+}
+
+// FullTextSearchHit is a single document matching a full text search. Its ID
+// is the accession number and document name, e.g.
+// 0000950170-23-002681:nicholas-20221231.htm.
+type FullTextSearchHit struct {
+	ID     string  `json:"_id"`
+	Score  float64 `json:"_score"`
+	Source struct {
+		CIKs            []string `json:"ciks"`
+		DisplayNames    []string `json:"display_names"`
+		Form            string   `json:"form"`
+		RootForms       []string `json:"root_forms"`
+		FileDate        string   `json:"file_date"`
+		PeriodEnding    string   `json:"period_ending"`
+		FileType        string   `json:"file_type"`
+		FileDescription *string  `json:"file_description"`
+		AccessionNumber string   `json:"adsh"`
+		Items           []string `json:"items"`
+	} `json:"_source"`
+	Highlight map[string][]string `json:"highlight"`
+}
+
+// DocumentName returns the file name of the matching document.
+func (h *FullTextSearchHit) DocumentName() string {
+	if i := strings.Index(h.ID, ":"); i >= 0 {
+		return h.ID[i+1:]
+	}
+	return ""
+}
+
+// Snippets returns the highlighted extracts of the hit, if any.
+func (h *FullTextSearchHit) Snippets() []string {
+	fields := make([]string, 0, len(h.Highlight))
+	for field := range h.Highlight {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	snippets := []string{}
+	for _, field := range fields {
+		snippets = append(snippets, h.Highlight[field]...)
+	}
+	return snippets
+}
+
+// Full text search methods
+// ------------------------
+
+// SearchFullText returns a page of FullTextSearchPageSize hits of a search
+// of the EDGAR full text archive, which covers filings since 2001.
+func (c *client) SearchFullText(query FullTextQuery) (*FullTextSearchResult, error) {
+	out := new(FullTextSearchResult)
+
+	params := url.Values{}
+	params.Set("q", query.Query)
+	if len(query.Forms) > 0 {
+		params.Set("forms", strings.Join(query.Forms, ","))
+	}
+	if len(query.CIKs) > 0 {
+		ciks := make([]string, len(query.CIKs))
+		for i, cik := range query.CIKs {
+			ciks[i] = PadCIK(cik)
+		}
+		params.Set("ciks", strings.Join(ciks, ","))
+	}
+	if query.StartDate != nil || query.EndDate != nil {
+		params.Set("dateRange", "custom")
+		params.Set("startdt", fullTextSearchEarliestDate)
+		if query.StartDate != nil {
+			params.Set("startdt", query.StartDate.Format(DateLayout))
+		}
+		params.Set("enddt", time.Now().UTC().Format(DateLayout))
+		if query.EndDate != nil {
+			params.Set("enddt", query.EndDate.Format(DateLayout))
+		}
+	}
+	if query.From > 0 {
+		params.Set("from", strconv.Itoa(query.From))
+	}
+
+	resp, err := c.request(http.MethodGet, c.secEFTSBaseURL+secSearchIndexPath+"?"+params.Encode(), nil)
+	if err != nil {
+		return out, err
+	}
+	err = unmarshall(resp, out)
+	return out, err
+}
@@ -0,0 +1,140 @@
+package edgar
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// dailyIndexLayout is the date layout of daily index file names and of the
+// dates within them.
+const dailyIndexLayout = "20060102"
+
+// IndexEntry is a filing listed in an EDGAR full or daily master index.
+type IndexEntry struct {
+	CIK             string
+	CompanyName     string
+	FormType        string
+	DateFiled       time.Time
+	Filename        string
+	AccessionNumber string
+	// URL of the complete submission text file
+	URL string
+}
+
+// Quarter returns the calendar quarter, 1 to 4, of a date.
+func Quarter(t time.Time) int {
+	return (int(t.Month())-1)/3 + 1
+}
+
+// Index methods
+// -------------
+
+// GetFullIndex returns every filing of a calendar quarter from the full
+// index master file. The index of the current quarter is updated nightly.
+func (c *client) GetFullIndex(year, quarter int) (*[]IndexEntry, error) {
+	folder := fmt.Sprintf("%s%s/%d/QTR%d", c.secArchivesBaseURL, secFullIndexPath, year, quarter)
+	return c.getIndex(folder+"/master.gz", folder+"/master.idx")
+}
+
+// GetDailyIndex returns every filing of a single day from the daily index
+// master file. Days without filings, e.g. weekends and holidays, have no
+// index and return an empty list.
+func (c *client) GetDailyIndex(date time.Time) (*[]IndexEntry, error) {
+	folder := fmt.Sprintf("%s%s/%d/QTR%d", c.secArchivesBaseURL, secDailyIndexPath, date.Year(), Quarter(date))
+	name := "master." + date.Format(dailyIndexLayout) + ".idx"
+	out, err := c.getIndex(folder+"/"+name+".gz", folder+"/"+name)
+	if isNotFound(err) {
+		return new([]IndexEntry), nil
+	}
+	return out, err
+}
+
+// getIndex downloads and parses a master index, preferring the gzip file and
+// falling back to the plain file, which older indexes are limited to.
+func (c *client) getIndex(gzipURL, plainURL string) (*[]IndexEntry, error) {
+	out := new([]IndexEntry)
+
+	resp, err := c.request(http.MethodGet, gzipURL, nil)
+	if err != nil {
+		return out, err
+	}
+	body, err := readAll(resp, 0)
+	if isNotFound(err) {
+		if resp, err = c.request(http.MethodGet, plainURL, nil); err != nil {
+			return out, err
+		}
+		body, err = readAll(resp, 0)
+	}
+	if err != nil {
+		return out, err
+	}
+
+	var r io.Reader = bytes.NewReader(body)
+	if len(body) > 1 && body[0] == 0x1f && body[1] == 0x8b {
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return out, err
+		}
+		defer zr.Close()
+		r = zr
+	}
+	*out, err = parseMasterIndex(r)
+	for i := range *out {
+		(*out)[i].URL = c.secArchivesBaseURL + "/" + (*out)[i].Filename
+	}
+	return out, err
+}
+
+// parseMasterIndex reads the pipe separated rows of a master index, which
+// follow a free text header and a line of dashes:
+//
+//	CIK|Company Name|Form Type|Date Filed|Filename
+//	--------------------------------------------------------------------------------
+//	320193|Apple Inc.|10-K|2023-11-03|edgar/data/320193/0000320193-23-000106.txt
+//
+// Full indexes write dates as YYYY-MM-DD, daily indexes as YYYYMMDD.
+func parseMasterIndex(r io.Reader) ([]IndexEntry, error) {
+	entries := []IndexEntry{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	inRows := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !inRows {
+			inRows = strings.HasPrefix(line, "-----")
+			continue
+		}
+		fields := strings.Split(line, "|")
+		if len(fields) != 5 {
+			continue
+		}
+		date, err := time.Parse(DateLayout, fields[3])
+		if err != nil {
+			if date, err = time.Parse(dailyIndexLayout, fields[3]); err != nil {
+				continue
+			}
+		}
+		entries = append(entries, IndexEntry{
+			CIK:             fields[0],
+			CompanyName:     fields[1],
+			FormType:        fields[2],
+			DateFiled:       date,
+			Filename:        fields[4],
+			AccessionNumber: strings.TrimSuffix(path.Base(fields[4]), ".txt"),
+		})
+	}
+	return entries, scanner.Err()
+}
+
+func isNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Response.StatusCode == http.StatusNotFound
+}
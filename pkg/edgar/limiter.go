@@ -0,0 +1,57 @@
+package edgar
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultSECRequestsPerSecond is the maximum request rate allowed by the SEC
+// fair access policy, see https://www.sec.gov/os/accessing-edgar-data
+const DefaultSECRequestsPerSecond = 10
+
+// secLimiter is shared by every client in the process, so that all
+// connections and concurrent hydrate calls together stay under the SEC limit.
+var secLimiter = newLimiter(DefaultSECRequestsPerSecond)
+
+// limiter is a token bucket holding a single token, refilled at a fixed rate.
+// Callers reserve a token up front and sleep until it is due, so waiting
+// requests are released in order and evenly spaced.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newLimiter(perSecond int) *limiter {
+	return &limiter{interval: time.Second / time.Duration(perSecond)}
+}
+
+// Wait blocks until the caller may send its request.
+func (l *limiter) Wait() {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// Limit lowers the rate to perSecond requests per second. The rate is never
+// raised, so the most conservative setting of any connection wins.
+func (l *limiter) Limit(perSecond int) {
+	if perSecond <= 0 {
+		return
+	}
+	interval := time.Second / time.Duration(perSecond)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if interval > l.interval {
+		l.interval = interval
+	}
+}
@@ -0,0 +1,63 @@
+package edgar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestLimiterPacing spaces waiting callers by the configured interval.
+func TestLimiterPacing(t *testing.T) {
+	l := newLimiter(20)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		l.Wait()
+	}
+	// the first token is free, the other four are 50ms apart
+	elapsed := time.Since(start)
+	require.GreaterOrEqual(t, elapsed, 200*time.Millisecond)
+	require.Less(t, elapsed, 400*time.Millisecond)
+}
+
+// TestLimiterLimit lowers the rate, but never raises it.
+func TestLimiterLimit(t *testing.T) {
+	l := newLimiter(10)
+	l.Limit(20)
+	require.Equal(t, 100*time.Millisecond, l.interval)
+	l.Limit(0)
+	require.Equal(t, 100*time.Millisecond, l.interval)
+	l.Limit(4)
+	require.Equal(t, 250*time.Millisecond, l.interval)
+}
+
+// TestLimiterPacesSECOnly paces SEC requests through the shared limiter,
+// while IEX requests go straight through.
+func TestLimiterPacesSECOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	saved := secLimiter
+	secLimiter = newLimiter(5)
+	defer func() { secLimiter = saved }()
+	c := newTestClient(server, 0)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := c.GetPublicCompanies()
+		require.NoError(t, err)
+	}
+	require.Less(t, time.Since(start), 150*time.Millisecond)
+
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := c.request(http.MethodGet, server.URL+"/files/company_tickers.json", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	require.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
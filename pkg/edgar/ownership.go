@@ -0,0 +1,120 @@
+package edgar
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// OwnershipForms are the insider ownership forms: initial statements (3),
+// changes (4) and annual statements (5) of beneficial ownership.
+var OwnershipForms = map[string]bool{
+	"3": true, "3/A": true, "4": true, "4/A": true, "5": true, "5/A": true,
+}
+
+var (
+	// ErrNoOwnershipDocument is returned for an ownership filing without a
+	// primary document.
+	ErrNoOwnershipDocument = errors.New("edgar: no ownership document in filing")
+	// ErrInvalidOwnershipDocument is returned when an ownership document is
+	// not well formed XML.
+	ErrInvalidOwnershipDocument = errors.New("edgar: invalid ownership document")
+)
+
+// OwnershipDocument is the XML document of a Form 3, 4 or 5 filing.
+type OwnershipDocument struct {
+	URL                       string                 `xml:"-"`
+	DocumentType              string                 `xml:"documentType"`
+	PeriodOfReport            string                 `xml:"periodOfReport"`
+	IssuerCIK                 string                 `xml:"issuer>issuerCik"`
+	IssuerName                string                 `xml:"issuer>issuerName"`
+	IssuerTradingSymbol       string                 `xml:"issuer>issuerTradingSymbol"`
+	ReportingOwners           []ReportingOwner       `xml:"reportingOwner"`
+	NonDerivativeTransactions []OwnershipTransaction `xml:"nonDerivativeTable>nonDerivativeTransaction"`
+	NonDerivativeHoldings     []OwnershipTransaction `xml:"nonDerivativeTable>nonDerivativeHolding"`
+	DerivativeTransactions    []OwnershipTransaction `xml:"derivativeTable>derivativeTransaction"`
+	DerivativeHoldings        []OwnershipTransaction `xml:"derivativeTable>derivativeHolding"`
+}
+
+// ReportingOwner is an insider reporting on a Form 3, 4 or 5, and their
+// relationship to the issuer.
+type ReportingOwner struct {
+	CIK               string  `xml:"reportingOwnerId>rptOwnerCik" json:"cik"`
+	Name              string  `xml:"reportingOwnerId>rptOwnerName" json:"name"`
+	IsDirector        XMLBool `xml:"reportingOwnerRelationship>isDirector" json:"is_director"`
+	IsOfficer         XMLBool `xml:"reportingOwnerRelationship>isOfficer" json:"is_officer"`
+	IsTenPercentOwner XMLBool `xml:"reportingOwnerRelationship>isTenPercentOwner" json:"is_ten_percent_owner"`
+	IsOther           XMLBool `xml:"reportingOwnerRelationship>isOther" json:"is_other"`
+	OfficerTitle      string  `xml:"reportingOwnerRelationship>officerTitle" json:"officer_title,omitempty"`
+	OtherText         string  `xml:"reportingOwnerRelationship>otherText" json:"other_text,omitempty"`
+}
+
+// OwnershipTransaction is a transaction or holding from the non-derivative
+// or derivative table of a Form 3, 4 or 5. Holdings have no transaction
+// fields, and only derivative securities have exercise and underlying fields.
+type OwnershipTransaction struct {
+	SecurityTitle                   string   `xml:"securityTitle>value"`
+	ConversionOrExercisePrice       *float64 `xml:"conversionOrExercisePrice>value"`
+	TransactionDate                 string   `xml:"transactionDate>value"`
+	TransactionFormType             string   `xml:"transactionCoding>transactionFormType"`
+	TransactionCode                 string   `xml:"transactionCoding>transactionCode"`
+	EquitySwapInvolved              XMLBool  `xml:"transactionCoding>equitySwapInvolved"`
+	Shares                          *float64 `xml:"transactionAmounts>transactionShares>value"`
+	PricePerShare                   *float64 `xml:"transactionAmounts>transactionPricePerShare>value"`
+	AcquiredDisposedCode            string   `xml:"transactionAmounts>transactionAcquiredDisposedCode>value"`
+	ExerciseDate                    string   `xml:"exerciseDate>value"`
+	ExpirationDate                  string   `xml:"expirationDate>value"`
+	UnderlyingSecurityTitle         string   `xml:"underlyingSecurity>underlyingSecurityTitle>value"`
+	UnderlyingSecurityShares        *float64 `xml:"underlyingSecurity>underlyingSecurityShares>value"`
+	SharesOwnedFollowingTransaction *float64 `xml:"postTransactionAmounts>sharesOwnedFollowingTransaction>value"`
+	DirectOrIndirectOwnership       string   `xml:"ownershipNature>directOrIndirectOwnership>value"`
+	NatureOfOwnership               string   `xml:"ownershipNature>natureOfOwnership>value"`
+}
+
+// XMLBool is a boolean flag of an ownership document, written as 1/0 or
+// true/false.
+type XMLBool bool
+
+func (b *XMLBool) UnmarshalText(text []byte) error {
+	switch strings.ToLower(strings.TrimSpace(string(text))) {
+	case "1", "true":
+		*b = true
+	default:
+		*b = false
+	}
+	return nil
+}
+
+// Ownership methods
+// -----------------
+
+// GetOwnershipDocument downloads and parses the XML document of a Form 3, 4
+// or 5 filing. The primary document of these filings is an XSL rendering,
+// e.g. xslF345X05/form4.xml, of the raw XML file at the root of the filing.
+func (c *client) GetOwnershipDocument(cik, accessionNumber, primaryDocument string) (*OwnershipDocument, error) {
+	out := new(OwnershipDocument)
+
+	if strings.TrimSpace(primaryDocument) == "" {
+		return out, fmt.Errorf("%w %s", ErrNoOwnershipDocument, accessionNumber)
+	}
+	documentURL, err := c.FilingDocumentURL(cik, accessionNumber, path.Base(primaryDocument))
+	if err != nil {
+		return out, err
+	}
+	resp, err := c.request(http.MethodGet, documentURL, nil)
+	if err != nil {
+		return out, err
+	}
+	body, err := readAll(resp, 0)
+	if err != nil {
+		return out, err
+	}
+	if err = xml.Unmarshal(body, out); err != nil {
+		return out, fmt.Errorf("%w %s: %v", ErrInvalidOwnershipDocument, documentURL, err)
+	}
+	out.URL = documentURL
+	return out, nil
+}
@@ -0,0 +1,113 @@
+package edgar
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultMaxRetries    = 3
+	defaultMinRetryDelay = 500 * time.Millisecond
+	defaultMaxRetryDelay = 30 * time.Second
+)
+
+// retryPolicy decides whether and when a failed request is sent again.
+type retryPolicy struct {
+	maxRetries int
+	minDelay   time.Duration
+	maxDelay   time.Duration
+}
+
+func newRetryPolicy(config Config) retryPolicy {
+	p := retryPolicy{
+		maxRetries: DefaultMaxRetries,
+		minDelay:   config.MinRetryDelay,
+		maxDelay:   config.MaxRetryDelay,
+	}
+	if config.MaxRetries != nil && *config.MaxRetries >= 0 {
+		p.maxRetries = *config.MaxRetries
+	}
+	if p.minDelay <= 0 {
+		p.minDelay = defaultMinRetryDelay
+	}
+	if p.maxDelay <= 0 {
+		p.maxDelay = defaultMaxRetryDelay
+	}
+	if p.maxDelay < p.minDelay {
+		p.maxDelay = p.minDelay
+	}
+	return p
+}
+
+// attempts returns how many times a request may be sent. Only idempotent
+// methods are retried, a failed POST may already have been applied.
+func (p retryPolicy) attempts(method string) int {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return 1 + p.maxRetries
+	}
+	return 1
+}
+
+// retryable reports whether the outcome of an attempt is worth retrying:
+// transport errors, throttling (429) and transient server errors.
+func retryable(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// delay returns how long to wait before the next attempt, after the given
+// attempt (starting at 1) failed. The backoff doubles on every attempt, with
+// jitter between half and the full value. A Retry-After header takes
+// precedence; false is returned if the server asks us to wait longer than
+// the maximum delay, as SEC does when an IP has been blocked.
+func (p retryPolicy) delay(attempt int, res *http.Response) (time.Duration, bool) {
+	if res != nil {
+		if after, ok := retryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+			if after > p.maxDelay {
+				return 0, false
+			}
+			return after, true
+		}
+	}
+
+	backoff := p.minDelay << (attempt - 1)
+	if backoff <= 0 || backoff > p.maxDelay {
+		backoff = p.maxDelay
+	}
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1)), true
+}
+
+// retryAfter parses a Retry-After header, given either in seconds or as an
+// HTTP date.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
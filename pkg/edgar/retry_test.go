@@ -0,0 +1,148 @@
+package edgar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client whose SEC and IEX endpoints all point at
+// the given test server, with short retry delays.
+func newTestClient(server *httptest.Server, maxRetries int) *client {
+	return NewClient(Config{
+		IEXToken:           "test-token",
+		UserAgent:          "Test Suite test@example.com",
+		IEXBaseURL:         server.URL + "/iex",
+		SECDataBaseURL:     server.URL,
+		SECArchivesBaseURL: server.URL + "/Archives",
+		SECFilesBaseURL:    server.URL + "/files",
+		SECEFTSBaseURL:     server.URL + "/efts",
+		MaxRetries:         Ptr(maxRetries),
+		MinRetryDelay:      time.Millisecond,
+		MaxRetryDelay:      2 * time.Second,
+	})
+}
+
+// TestRetryOnServiceUnavailable retries a GET until SEC stops returning 503.
+func TestRetryOnServiceUnavailable(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Test Suite test@example.com", r.Header.Get("User-Agent"))
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"cik": "1650373", "name": "Test Co"}`))
+	}))
+	defer server.Close()
+
+	result, err := newTestClient(server, 3).GetSubmissions("0001650373")
+	require.NoError(t, err)
+	require.Equal(t, "Test Co", *result.Name)
+	require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+}
+
+// TestRetryHonoursRetryAfter waits for the Retry-After delay before retrying.
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"cik": "1650373"}`))
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	_, err := newTestClient(server, 3).GetSubmissions("0001650373")
+	require.NoError(t, err)
+	require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+// TestRetryGivesUpOnLongRetryAfter does not wait when the server asks for
+// longer than the maximum retry delay.
+func TestRetryGivesUpOnLongRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server, 3).GetSubmissions("0001650373")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusTooManyRequests, apiErr.Response.StatusCode)
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+// TestRetryExhausted returns the last error response once retries run out.
+func TestRetryExhausted(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server, 2).GetPublicCompanies()
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusInternalServerError, apiErr.Response.StatusCode)
+	require.NotContains(t, err.Error(), "test-token")
+	require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+}
+
+// TestNoRetryOnClientError does not retry errors that will not go away.
+func TestNoRetryOnClientError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server, 3).GetSubmissions("0000000000")
+	require.Error(t, err)
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+// TestNoRetryOnPost never retries requests that are not idempotent.
+func TestNoRetryOnPost(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	res, err := newTestClient(server, 3).request(http.MethodPost, server.URL+"/search", map[string]string{"q": "test"})
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+// TestRetryAfter parses both forms of the Retry-After header.
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	d, ok := retryAfter("120", now)
+	require.True(t, ok)
+	require.Equal(t, 2*time.Minute, d)
+
+	d, ok = retryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now)
+	require.True(t, ok)
+	require.Equal(t, 30*time.Second, d)
+
+	_, ok = retryAfter("soon", now)
+	require.False(t, ok)
+}
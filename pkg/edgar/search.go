@@ -1,7 +1,12 @@
 package edgar
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -27,6 +32,20 @@ type Company struct {
 	LEI                 *string `json:"lei"`
 }
 
+// companyTickersExchange is the column major layout of company_tickers_exchange.json,
+// e.g. {"fields": ["cik", "name", "ticker", "exchange"], "data": [[320193, "Apple Inc.", "AAPL", "Nasdaq"]]}
+type companyTickersExchange struct {
+	Fields []string        `json:"fields"`
+	Data   [][]interface{} `json:"data"`
+}
+
+// companyTicker is a single entry of company_tickers.json, which is keyed by row number.
+type companyTicker struct {
+	CIK    int64  `json:"cik_str"`
+	Ticker string `json:"ticker"`
+	Title  string `json:"title"`
+}
+
 // SubmissionsSearchResult is a single sec filer.
 type SubmissionsSearchResult struct {
 	CIK                               *string       `json:"cik"`
@@ -74,8 +93,17 @@ type NameRecord struct {
 }
 
 type FilingRecord struct {
-	Recent *FilingTable `json:"recent"`
-	Files  *[]string    `json:"file"`
+	Recent *FilingTable   `json:"recent"`
+	Files  *[]FilingsFile `json:"files"`
+}
+
+// FilingsFile describes an additional submissions file holding filings older
+// than those in FilingRecord.Recent, e.g. CIK0000320193-submissions-001.json.
+type FilingsFile struct {
+	Name        string `json:"name"`
+	FilingCount int    `json:"filingCount"`
+	FilingFrom  string `json:"filingFrom"`
+	FilingTo    string `json:"filingTo"`
 }
 
 type FilingTable struct {
@@ -100,8 +128,8 @@ type Filing struct {
 	CIK                   *string    `json:"cik"`       // NOTE: this is a hack for easy unpacking of values in steampipe
 	IndexURL              *string    `json:"index_url"` // NOTE: this is a hack for easy unpacking of values in steampipe
 	AccessionNumber       *string    `json:"accessionNumber"`
-	FilingDate            *string    `json:"filingDate"`
-	ReportDate            *string    `json:"reportDate"`
+	FilingDate            *time.Time `json:"filingDate"`
+	ReportDate            *time.Time `json:"reportDate"`
 	AcceptanceDateTime    *time.Time `json:"acceptanceDateTime"`
 	Act                   *string    `json:"act"`
 	Form                  *string    `json:"form"`
@@ -115,6 +143,18 @@ type Filing struct {
 	PrimaryDocDescription *string    `json:"primaryDocDescription"`
 }
 
+// SplitItems splits the comma separated item codes of an 8-K filing,
+// e.g. "2.02,9.01", into a list.
+func SplitItems(items string) []string {
+	codes := []string{}
+	for _, item := range strings.Split(items, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			codes = append(codes, item)
+		}
+	}
+	return codes
+}
+
 // Search methods
 // ------------------
 
@@ -122,7 +162,7 @@ type Filing struct {
 func (c *client) GetPublicCompanies() (*[]Company, error) {
 	out := new([]Company)
 
-	resp, err := c.request(http.MethodGet, iexSymbolsURL, nil)
+	resp, err := c.request(http.MethodGet, c.iexBaseURL+iexSymbolsPath, nil)
 	if err != nil {
 		return out, err
 	}
@@ -131,24 +171,138 @@ func (c *client) GetPublicCompanies() (*[]Company, error) {
 	return out, err
 }
 
+// GetSECCompanies returns the list of public companies with a ticker
+// published by SEC in company_tickers_exchange.json, falling back to
+// company_tickers.json, which has no exchange, if that cannot be loaded.
+// Unlike GetPublicCompanies it needs no IEX subscription.
+func (c *client) GetSECCompanies() (*[]Company, error) {
+	out, err := c.getCompanyTickersExchange()
+	if err == nil {
+		return out, nil
+	}
+	if errors.Is(err, ErrMissingUserAgent) {
+		return out, err
+	}
+	return c.getCompanyTickers()
+}
+
+func (c *client) getCompanyTickersExchange() (*[]Company, error) {
+	out := new([]Company)
+	result := new(companyTickersExchange)
+
+	resp, err := c.request(http.MethodGet, c.secFilesBaseURL+secTickersExchangePath, nil)
+	if err != nil {
+		return out, err
+	}
+	if err = unmarshall(resp, result); err != nil {
+		return out, err
+	}
+
+	fields := map[string]int{}
+	for i, f := range result.Fields {
+		fields[f] = i
+	}
+	value := func(row []interface{}, field string) *string {
+		i, ok := fields[field]
+		if !ok || i >= len(row) || row[i] == nil {
+			return nil
+		}
+		switch v := row[i].(type) {
+		case string:
+			return &v
+		case float64:
+			return Ptr(strconv.FormatInt(int64(v), 10))
+		}
+		return Ptr(fmt.Sprint(row[i]))
+	}
+
+	for _, row := range result.Data {
+		company := Company{
+			Symbol:   value(row, "ticker"),
+			Name:     value(row, "name"),
+			Exchange: value(row, "exchange"),
+		}
+		if cik := value(row, "cik"); cik != nil {
+			company.CIK = Ptr(PadCIK(*cik))
+		}
+		*out = append(*out, company)
+	}
+	return out, nil
+}
+
+func (c *client) getCompanyTickers() (*[]Company, error) {
+	out := new([]Company)
+	result := map[string]companyTicker{}
+
+	resp, err := c.request(http.MethodGet, c.secFilesBaseURL+secTickersPath, nil)
+	if err != nil {
+		return out, err
+	}
+	if err = unmarshall(resp, &result); err != nil {
+		return out, err
+	}
+
+	// keys are row numbers, keep SEC's ordering
+	keys := make([]int, 0, len(result))
+	for k := range result {
+		i, err := strconv.Atoi(k)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, i)
+	}
+	sort.Ints(keys)
+
+	for _, k := range keys {
+		t := result[strconv.Itoa(k)]
+		*out = append(*out, Company{
+			Symbol: Ptr(t.Ticker),
+			Name:   Ptr(t.Title),
+			CIK:    Ptr(PadCIK(strconv.FormatInt(t.CIK, 10))),
+		})
+	}
+	return out, nil
+}
+
+// PadCIK returns the CIK as the 10 digit, zero padded string used in EDGAR URLs.
+func PadCIK(cik string) string {
+	if len(cik) >= 10 {
+		return cik
+	}
+	return fmt.Sprintf("%010s", cik)
+}
+
 // Filings methods
 // -----------------
 
-// TODO: handle pagination in the SEC Edgar API
-// GetFilings gets a list of filings for a single CIK.
+// GetSubmissions gets the filer details and most recent filings for a single
+// CIK. Older filings are listed in Filings.Files, see GetSubmissionsFile.
 func (c *client) GetSubmissions(cik string) (submissions *SubmissionsSearchResult, err error) {
 	submissions = new(SubmissionsSearchResult)
 
-	url := secCompanyURL + "CIK" + cik + ".json"
+	url := c.secDataBaseURL + secSubmissionsPath + "CIK" + cik + ".json"
 	// url := "https://data.sec.gov/submissions/CIK0001650373.json"
 	resp, err := c.request(http.MethodGet, url, nil)
 
 	if err != nil {
 		return submissions, err
 	}
-	// NOTE: sleep for 100ms
-	time.Sleep(100 * time.Millisecond)
-
 	err = unmarshall(resp, submissions)
 	return submissions, err
 }
+
+// GetSubmissionsFile gets one of the additional submissions files listed in
+// FilingRecord.Files, which has the same layout as FilingRecord.Recent.
+func (c *client) GetSubmissionsFile(name string) (filings *FilingTable, err error) {
+	filings = new(FilingTable)
+
+	url := c.secDataBaseURL + secSubmissionsPath + name
+	resp, err := c.request(http.MethodGet, url, nil)
+
+	if err != nil {
+		return filings, err
+	}
+
+	err = unmarshall(resp, filings)
+	return filings, err
+}
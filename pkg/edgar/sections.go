@@ -0,0 +1,217 @@
+package edgar
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Section is one item of a 10-K or 10-Q report, e.g. Item 1A Risk Factors.
+type Section struct {
+	Part  string
+	Item  string
+	Title string
+	Text  string
+}
+
+// sectionLayout is the standard part and item of a report section.
+type sectionLayout struct {
+	Part  string
+	Item  string
+	Title string
+}
+
+// tenKLayout lists the items of Form 10-K in order.
+var tenKLayout = []sectionLayout{
+	{"I", "1", "Business"},
+	{"I", "1A", "Risk Factors"},
+	{"I", "1B", "Unresolved Staff Comments"},
+	{"I", "1C", "Cybersecurity"},
+	{"I", "2", "Properties"},
+	{"I", "3", "Legal Proceedings"},
+	{"I", "4", "Mine Safety Disclosures"},
+	{"II", "5", "Market for Registrant's Common Equity, Related Stockholder Matters and Issuer Purchases of Equity Securities"},
+	{"II", "6", "[Reserved]"},
+	{"II", "7", "Management's Discussion and Analysis of Financial Condition and Results of Operations"},
+	{"II", "7A", "Quantitative and Qualitative Disclosures About Market Risk"},
+	{"II", "8", "Financial Statements and Supplementary Data"},
+	{"II", "9", "Changes in and Disagreements with Accountants on Accounting and Financial Disclosure"},
+	{"II", "9A", "Controls and Procedures"},
+	{"II", "9B", "Other Information"},
+	{"II", "9C", "Disclosure Regarding Foreign Jurisdictions that Prevent Inspections"},
+	{"III", "10", "Directors, Executive Officers and Corporate Governance"},
+	{"III", "11", "Executive Compensation"},
+	{"III", "12", "Security Ownership of Certain Beneficial Owners and Management and Related Stockholder Matters"},
+	{"III", "13", "Certain Relationships and Related Transactions, and Director Independence"},
+	{"III", "14", "Principal Accountant Fees and Services"},
+	{"IV", "15", "Exhibits and Financial Statement Schedules"},
+	{"IV", "16", "Form 10-K Summary"},
+}
+
+// tenQLayout lists the items of Form 10-Q in order. Item numbers restart in
+// part II, so sections are matched on both part and item.
+var tenQLayout = []sectionLayout{
+	{"I", "1", "Financial Statements"},
+	{"I", "2", "Management's Discussion and Analysis of Financial Condition and Results of Operations"},
+	{"I", "3", "Quantitative and Qualitative Disclosures About Market Risk"},
+	{"I", "4", "Controls and Procedures"},
+	{"II", "1", "Legal Proceedings"},
+	{"II", "1A", "Risk Factors"},
+	{"II", "2", "Unregistered Sales of Equity Securities and Use of Proceeds"},
+	{"II", "3", "Defaults Upon Senior Securities"},
+	{"II", "4", "Mine Safety Disclosures"},
+	{"II", "5", "Other Information"},
+	{"II", "6", "Exhibits"},
+}
+
+var (
+	partHeading = regexp.MustCompile(`(?i)^part\s+(iv|i{1,3})\b`)
+	itemHeading = regexp.MustCompile(`(?i)^(?:part\s+(?:iv|i{1,3})\b[\s.,:\-–—]*)?item\s+(\d{1,2}[a-c]?)\b`)
+)
+
+// sectionHeading is a line of the document that starts a section.
+type sectionHeading struct {
+	layout int // index into the form layout
+	start  int // offset of the heading line
+	body   int // offset of the line after the heading
+	cut    int // where the previous section ends, before any part heading
+}
+
+// formLayout returns the standard items of a 10-K or 10-Q form, including
+// amendments and transition reports, or nil for other forms.
+func formLayout(form string) []sectionLayout {
+	form = strings.ToUpper(form)
+	switch {
+	case strings.HasPrefix(form, "10-K"):
+		return tenKLayout
+	case strings.HasPrefix(form, "10-Q"):
+		return tenQLayout
+	}
+	return nil
+}
+
+// SplitSections splits the plain text of a 10-K or 10-Q primary document,
+// as returned by HTMLToText, into its items. Headings are lines starting with
+// "Item <n>". A table of contents repeats every heading, so for each item the
+// heading followed by the most text is used. Items missing from the document
+// are left out. Returns nil for other forms.
+func SplitSections(form, text string) []Section {
+	layout := formLayout(form)
+	if layout == nil {
+		return nil
+	}
+
+	// every line that looks like the heading of a known item
+	var headings []sectionHeading
+	part := ""
+	partLine := -1
+	offset := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if m := partHeading.FindStringSubmatch(trimmed); m != nil {
+			part = strings.ToUpper(m[1])
+			partLine = offset
+		}
+		if m := itemHeading.FindStringSubmatch(trimmed); m != nil {
+			if i := findSection(layout, part, strings.ToUpper(m[1])); i >= 0 {
+				h := sectionHeading{layout: i, start: offset, body: offset + len(line), cut: offset}
+				if partLine >= 0 {
+					h.cut = partLine
+				}
+				headings = append(headings, h)
+			}
+		}
+		if trimmed != "" && partLine != offset {
+			partLine = -1
+		}
+		offset += len(line)
+	}
+
+	// keep the heading with the longest span of each item
+	best := map[int]sectionHeading{}
+	bestSpan := map[int]int{}
+	for i, h := range headings {
+		end := len(text)
+		if i+1 < len(headings) {
+			end = headings[i+1].start
+		}
+		if span := end - h.start; span > bestSpan[h.layout] {
+			best[h.layout] = h
+			bestSpan[h.layout] = span
+		}
+	}
+	chosen := make([]sectionHeading, 0, len(best))
+	for _, h := range best {
+		chosen = append(chosen, h)
+	}
+	sort.Slice(chosen, func(i, j int) bool { return chosen[i].start < chosen[j].start })
+
+	// drop headings that are out of order, e.g. a cross reference to another
+	// item that happens to start a line
+	ordered := inOrder(chosen)
+
+	sections := make([]Section, 0, len(ordered))
+	for i, h := range ordered {
+		end := len(text)
+		if i+1 < len(ordered) {
+			end = ordered[i+1].cut
+		}
+		l := layout[h.layout]
+		sections = append(sections, Section{
+			Part:  l.Part,
+			Item:  l.Item,
+			Title: l.Title,
+			Text:  strings.TrimSpace(text[h.body:end]),
+		})
+	}
+	return sections
+}
+
+// findSection returns the index of an item in the layout, using the part
+// for forms that repeat item numbers, or -1 if it is not a standard item.
+func findSection(layout []sectionLayout, part, item string) int {
+	found := -1
+	for i, l := range layout {
+		if l.Item != item {
+			continue
+		}
+		if l.Part == part {
+			return i
+		}
+		// before any part heading, or when the part is unknown, take the
+		// first match
+		if found < 0 {
+			found = i
+		}
+	}
+	return found
+}
+
+// inOrder returns the longest run of headings, in document order, whose
+// items are also in layout order.
+func inOrder(headings []sectionHeading) []sectionHeading {
+	if len(headings) == 0 {
+		return headings
+	}
+	// length of the longest ordered run ending at each heading, and the
+	// heading before it in that run
+	length := make([]int, len(headings))
+	prev := make([]int, len(headings))
+	last := 0
+	for i := range headings {
+		length[i], prev[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if headings[j].layout < headings[i].layout && length[j]+1 > length[i] {
+				length[i], prev[i] = length[j]+1, j
+			}
+		}
+		if length[i] > length[last] {
+			last = i
+		}
+	}
+	ordered := make([]sectionHeading, length[last])
+	for i, k := len(ordered)-1, last; i >= 0; i, k = i-1, prev[k] {
+		ordered[i] = headings[k]
+	}
+	return ordered
+}
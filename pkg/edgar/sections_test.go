@@ -0,0 +1,65 @@
+package edgar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitSections10K(t *testing.T) {
+	text := `Apple Inc.
+Table of Contents
+Part I
+Item 1. Business 1
+Item 1A. Risk Factors 5
+Part II
+Item 7. Management's Discussion and Analysis 20
+Item 7A. Quantitative and Qualitative Disclosures About Market Risk 30
+
+PART I
+Item 1. Business
+Company Background
+The Company designs, manufactures and markets smartphones.
+Item 1A. Risk Factors
+The Company's business can be affected by a number of factors.
+See Item 7 for further discussion.
+Item 7 of this report discusses liquidity.
+PART II
+Item 7. Management's Discussion and Analysis of Financial Condition and Results of Operations
+Net sales increased during 2023 compared to 2022 for several reasons.
+Item 7A. Quantitative and Qualitative Disclosures About Market Risk
+Interest rate risk.`
+
+	sections := SplitSections("10-K", text)
+	require.Len(t, sections, 4)
+	require.Equal(t, "I", sections[1].Part)
+	require.Equal(t, "1A", sections[1].Item)
+	require.Equal(t, "Risk Factors", sections[1].Title)
+	require.Equal(t, "The Company's business can be affected by a number of factors.\nSee Item 7 for further discussion.\nItem 7 of this report discusses liquidity.", sections[1].Text)
+	require.Equal(t, "7", sections[2].Item)
+	require.Equal(t, "Net sales increased during 2023 compared to 2022 for several reasons.", sections[2].Text)
+	require.Equal(t, "Interest rate risk.", sections[3].Text)
+}
+
+func TestSplitSections10Q(t *testing.T) {
+	text := `PART I — FINANCIAL INFORMATION
+Item 1. Financial Statements
+Condensed consolidated statements of operations.
+Item 2. Management's Discussion and Analysis
+Quarterly results.
+PART II — OTHER INFORMATION
+Item 1. Legal Proceedings
+Epic Games litigation.
+Item 1A. Risk Factors
+No material changes.`
+
+	sections := SplitSections("10-Q/A", text)
+	require.Len(t, sections, 4)
+	require.Equal(t, "II", sections[2].Part)
+	require.Equal(t, "1", sections[2].Item)
+	require.Equal(t, "Legal Proceedings", sections[2].Title)
+	require.Equal(t, "Epic Games litigation.", sections[2].Text)
+	require.Equal(t, "1A", sections[3].Item)
+
+	require.Nil(t, SplitSections("8-K", text))
+}
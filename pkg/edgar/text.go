@@ -0,0 +1,181 @@
+package edgar
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// DefaultMaxDocumentSize caps how much of a document GetDocument downloads.
+const DefaultMaxDocumentSize = 25 * 1024 * 1024
+
+// ErrNotArchivesURL is returned by GetDocument for URLs outside the SEC
+// archives, so that the SEC user agent is never sent to other hosts.
+var ErrNotArchivesURL = errors.New("edgar: document URL is not in the SEC archives")
+
+// Document is a downloaded filing document.
+type Document struct {
+	URL         string
+	ContentType string
+	Body        []byte
+	// Truncated is set when the document was larger than the size limit.
+	Truncated bool
+}
+
+// IsHTML reports whether the document is an HTML or iXBRL page.
+func (d *Document) IsHTML() bool {
+	mediaType, _, _ := mime.ParseMediaType(d.ContentType)
+	if mediaType == "text/html" || mediaType == "application/xhtml+xml" {
+		return true
+	}
+	head := d.Body
+	if len(head) > 512 {
+		head = head[:512]
+	}
+	return mediaType == "" && bytes.Contains(bytes.ToLower(head), []byte("<html"))
+}
+
+// IsText reports whether the document has a textual content type.
+func (d *Document) IsText() bool {
+	mediaType, _, _ := mime.ParseMediaType(d.ContentType)
+	return strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "xml") || d.IsHTML()
+}
+
+// Text returns the plain text of the document, stripping HTML and iXBRL
+// markup, or an empty string for binary documents such as PDFs and images.
+func (d *Document) Text() string {
+	switch {
+	case d.IsHTML():
+		return HTMLToText(d.Body)
+	case d.IsText():
+		return string(d.Body)
+	}
+	return ""
+}
+
+// GetDocument downloads a document from the SEC archives, reading at most
+// maxSize bytes, or DefaultMaxDocumentSize if maxSize is not positive.
+func (c *client) GetDocument(documentURL string, maxSize int64) (*Document, error) {
+	if !strings.HasPrefix(documentURL, c.secArchivesBaseURL+"/") {
+		return nil, fmt.Errorf("%w: %s", ErrNotArchivesURL, documentURL)
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxDocumentSize
+	}
+
+	resp, err := c.request(http.MethodGet, documentURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	contentType := resp.Header.Get("Content-Type")
+	body, err := readAll(resp, maxSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{URL: documentURL, ContentType: contentType, Body: body}
+	if int64(len(body)) > maxSize {
+		doc.Body = body[:maxSize]
+		doc.Truncated = true
+	}
+	return doc, nil
+}
+
+// blockElements start a new line in the extracted text.
+var blockElements = map[string]bool{
+	"address": true, "article": true, "blockquote": true, "br": true, "dd": true, "div": true,
+	"dl": true, "dt": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"hr": true, "li": true, "ol": true, "p": true, "pre": true, "section": true, "table": true,
+	"tr": true, "ul": true,
+}
+
+// skippedElements have no readable content. ix:header holds the hidden iXBRL
+// facts and contexts of an inline XBRL document.
+var skippedElements = map[string]bool{
+	"head": true, "script": true, "style": true, "title": true, "ix:header": true,
+}
+
+var (
+	spaces     = regexp.MustCompile(`[ \t\x{00a0}]+`)
+	blankLines = regexp.MustCompile(`\n\s*\n+`)
+	hidden     = regexp.MustCompile(`display:\s*none`)
+)
+
+// HTMLToText extracts the readable text of an HTML or iXBRL document, with
+// one line per block element and table cells separated by spaces.
+func HTMLToText(page []byte) string {
+	// open elements, and whether each one is hidden or inside a hidden element
+	type element struct {
+		tag    string
+		hidden bool
+	}
+	var b strings.Builder
+	var stack []element
+	skip := 0
+
+	z := html.NewTokenizer(bytes.NewReader(page))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			text := spaces.ReplaceAllString(b.String(), " ")
+			text = strings.ReplaceAll(text, " \n", "\n")
+			text = strings.ReplaceAll(text, "\n ", "\n")
+			text = blankLines.ReplaceAllString(text, "\n\n")
+			return strings.TrimSpace(text)
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			tag := string(name)
+			if blockElements[tag] {
+				b.WriteString("\n")
+			} else if tag == "td" || tag == "th" {
+				b.WriteString(" ")
+			}
+			if tt == html.SelfClosingTagToken || tag == "br" || tag == "hr" || tag == "img" || tag == "meta" || tag == "link" || tag == "input" {
+				continue
+			}
+			isHidden := skippedElements[tag]
+			for hasAttr && !isHidden {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+				if string(key) == "style" && hidden.Match(bytes.ToLower(val)) {
+					isHidden = true
+				}
+			}
+			isHidden = isHidden || skip > 0
+			if isHidden {
+				skip++
+			}
+			stack = append(stack, element{tag: tag, hidden: isHidden})
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			// pop back to the matching start tag, tolerating unclosed elements
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i].tag != tag {
+					continue
+				}
+				for _, e := range stack[i:] {
+					if e.hidden {
+						skip--
+					}
+				}
+				stack = stack[:i]
+				break
+			}
+			if blockElements[tag] {
+				b.WriteString("\n")
+			}
+		case html.TextToken:
+			if skip == 0 {
+				b.Write(z.Text())
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package edgar
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ThirteenFForms are the quarterly holdings reports of institutional
+// investment managers, and their amendments.
+var ThirteenFForms = map[string]bool{
+	"13F-HR": true, "13F-HR/A": true,
+}
+
+// informationTableType is the document type of the holdings list of a 13F-HR.
+const informationTableType = "INFORMATION TABLE"
+
+// ErrNoInformationTable is returned for a 13F-HR filing without an XML
+// information table, e.g. the text-only reports filed before the 2013 switch
+// to XML, and notice filings that list no holdings.
+var ErrNoInformationTable = errors.New("edgar: no information table in filing")
+
+// InformationTable is the list of holdings of a 13F-HR filing.
+type InformationTable struct {
+	URL      string                 `xml:"-"`
+	Holdings []InstitutionalHolding `xml:"infoTable"`
+}
+
+// InstitutionalHolding is one position of a 13F-HR information table. Value
+// is in dollars for reports filed from January 2023, and in thousands of
+// dollars before that.
+type InstitutionalHolding struct {
+	NameOfIssuer            string   `xml:"nameOfIssuer"`
+	TitleOfClass            string   `xml:"titleOfClass"`
+	CUSIP                   string   `xml:"cusip"`
+	FIGI                    string   `xml:"figi"`
+	Value                   *float64 `xml:"value"`
+	SharesOrPrincipalAmount *float64 `xml:"shrsOrPrnAmt>sshPrnamt"`
+	SharesOrPrincipalType   string   `xml:"shrsOrPrnAmt>sshPrnamtType"`
+	PutCall                 string   `xml:"putCall"`
+	InvestmentDiscretion    string   `xml:"investmentDiscretion"`
+	OtherManager            string   `xml:"otherManager"`
+	VotingAuthoritySole     *int64   `xml:"votingAuthority>Sole"`
+	VotingAuthorityShared   *int64   `xml:"votingAuthority>Shared"`
+	VotingAuthorityNone     *int64   `xml:"votingAuthority>None"`
+}
+
+// 13F methods
+// -----------
+
+// GetInformationTable finds the information table XML document of a 13F-HR
+// filing among its documents, and parses its holdings.
+func (c *client) GetInformationTable(cik, accessionNumber string) (*InformationTable, error) {
+	out := new(InformationTable)
+
+	documents, err := c.GetFilingDocuments(cik, accessionNumber)
+	if err != nil {
+		return out, err
+	}
+	documentURL := ""
+	for _, doc := range *documents {
+		if doc.Type != nil && strings.EqualFold(*doc.Type, informationTableType) && strings.HasSuffix(strings.ToLower(doc.Name), ".xml") {
+			documentURL = doc.URL
+			break
+		}
+	}
+	if documentURL == "" {
+		return out, fmt.Errorf("%w %s", ErrNoInformationTable, accessionNumber)
+	}
+
+	resp, err := c.request(http.MethodGet, documentURL, nil)
+	if err != nil {
+		return out, err
+	}
+	body, err := readAll(resp, 0)
+	if err != nil {
+		return out, err
+	}
+	if err = xml.Unmarshal(body, out); err != nil {
+		return out, err
+	}
+	out.URL = documentURL
+	return out, nil
+}
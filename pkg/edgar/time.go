@@ -7,6 +7,26 @@ import (
 	"time"
 )
 
+// DateLayout is the layout of EDGAR dates, e.g. filingDate and reportDate.
+const DateLayout = "2006-01-02"
+
+// ParseDate parses an EDGAR date, returning nil for empty or invalid values.
+// A trailing time zone offset, e.g. 2023-10-02-05:00 in ownership documents,
+// is ignored.
+func ParseDate(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	if len(value) > len(DateLayout) {
+		value = value[:len(DateLayout)]
+	}
+	t, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
 // Time wraps time.Time with serialization support.
 type Time struct{ time.Time }
 
@@ -0,0 +1,122 @@
+package edgar
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// CompanyFacts is every XBRL fact reported by a single filer, keyed by
+// taxonomy (e.g. us-gaap, dei) and then by concept (e.g. Revenues).
+type CompanyFacts struct {
+	CIK        *int64                         `json:"cik"`
+	EntityName *string                        `json:"entityName"`
+	Facts      map[string]map[string]*Concept `json:"facts"`
+}
+
+// Concept is the reported values of a single XBRL concept, keyed by unit of
+// measure (e.g. USD, shares, USD/shares).
+type Concept struct {
+	Label       *string           `json:"label"`
+	Description *string           `json:"description"`
+	Units       map[string][]Fact `json:"units"`
+}
+
+// Fact is a single reported value of a concept. Start is only set for
+// duration concepts, instant concepts are reported at End.
+type Fact struct {
+	Start *string  `json:"start"`
+	End   *string  `json:"end"`
+	Val   *float64 `json:"val"`
+	Accn  *string  `json:"accn"`
+	FY    *int     `json:"fy"`
+	FP    *string  `json:"fp"`
+	Form  *string  `json:"form"`
+	Filed *string  `json:"filed"`
+	Frame *string  `json:"frame"`
+}
+
+// CompanyConcept is every reported value of a single XBRL concept for a
+// single filer, keyed by unit of measure.
+type CompanyConcept struct {
+	CIK         *int64            `json:"cik"`
+	Taxonomy    *string           `json:"taxonomy"`
+	Tag         *string           `json:"tag"`
+	Label       *string           `json:"label"`
+	Description *string           `json:"description"`
+	EntityName  *string           `json:"entityName"`
+	Units       map[string][]Fact `json:"units"`
+}
+
+// Frame is one value of a concept for every filer that reported it for a
+// calendar period, e.g. CY2023Q4 for a duration or CY2023Q4I for an instant.
+type Frame struct {
+	Taxonomy    *string      `json:"taxonomy"`
+	Tag         *string      `json:"tag"`
+	CCP         *string      `json:"ccp"`
+	UOM         *string      `json:"uom"`
+	Label       *string      `json:"label"`
+	Description *string      `json:"description"`
+	Pts         *int         `json:"pts"`
+	Data        []FrameValue `json:"data"`
+}
+
+// FrameValue is the value reported by a single filer within a Frame.
+type FrameValue struct {
+	Accn       *string  `json:"accn"`
+	CIK        *int64   `json:"cik"`
+	EntityName *string  `json:"entityName"`
+	Loc        *string  `json:"loc"`
+	Start      *string  `json:"start"`
+	End        *string  `json:"end"`
+	Val        *float64 `json:"val"`
+}
+
+// XBRL methods
+// -----------------
+
+// GetCompanyFacts gets all XBRL facts reported by a single CIK.
+func (c *client) GetCompanyFacts(cik string) (facts *CompanyFacts, err error) {
+	facts = new(CompanyFacts)
+
+	url := c.secDataBaseURL + secCompanyFactsPath + "CIK" + PadCIK(cik) + ".json"
+	resp, err := c.request(http.MethodGet, url, nil)
+
+	if err != nil {
+		return facts, err
+	}
+
+	err = unmarshall(resp, facts)
+	return facts, err
+}
+
+// GetCompanyConcept gets the values of a single XBRL concept, e.g. us-gaap
+// AccountsPayableCurrent, reported by a single CIK.
+func (c *client) GetCompanyConcept(cik, taxonomy, tag string) (concept *CompanyConcept, err error) {
+	concept = new(CompanyConcept)
+
+	endpoint := c.secDataBaseURL + secCompanyConceptPath + "CIK" + PadCIK(cik) + "/" + url.PathEscape(taxonomy) + "/" + url.PathEscape(tag) + ".json"
+	resp, err := c.request(http.MethodGet, endpoint, nil)
+
+	if err != nil {
+		return concept, err
+	}
+
+	err = unmarshall(resp, concept)
+	return concept, err
+}
+
+// GetFrame gets the value of a concept in a single unit, e.g. USD, for every
+// filer that reported it for the calendar period, e.g. CY2023Q4I.
+func (c *client) GetFrame(taxonomy, tag, unit, period string) (frame *Frame, err error) {
+	frame = new(Frame)
+
+	endpoint := c.secDataBaseURL + secFramesPath + url.PathEscape(taxonomy) + "/" + url.PathEscape(tag) + "/" + url.PathEscape(unit) + "/" + url.PathEscape(period) + ".json"
+	resp, err := c.request(http.MethodGet, endpoint, nil)
+
+	if err != nil {
+		return frame, err
+	}
+
+	err = unmarshall(resp, frame)
+	return frame, err
+}